@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"regexp"
 
 	"github.com/half-ogre/go-kit/actionskit"
 )
@@ -41,6 +42,9 @@ type Result struct {
 	Error     error
 }
 
+// repositoryPattern matches an owner/name repository reference
+var repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
 func main() {
 	config, err := getConfigFromEnvironment()
 	if err != nil {
@@ -59,10 +63,16 @@ func main() {
 
 // getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
 func getConfigFromEnvironment() (*Config, error) {
-	repository := os.Getenv("GITHUB_REPOSITORY")
+	repository := actionskit.GetInput("repository")
+	if repository == "" {
+		repository = os.Getenv("GITHUB_REPOSITORY")
+	}
 	if repository == "" {
 		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
 	}
+	if !repositoryPattern.MatchString(repository) {
+		return nil, fmt.Errorf("repository must be in owner/name format, got %q", repository)
+	}
 
 	issueNumber := actionskit.GetInput("issue-number")
 	if issueNumber == "" {
@@ -98,7 +108,7 @@ func run(config *Config) *Result {
 		actionskit.Info(fmt.Sprintf("Adding comment before closing issue #%s", config.IssueNumber))
 		commentID, err := addComment(config.Repository, config.IssueNumber, config.CommentBody, config.Token)
 		if err != nil {
-			result.Error = fmt.Errorf("error adding comment: %v", err)
+			result.Error = fmt.Errorf("error adding comment to %s#%s: %v", config.Repository, config.IssueNumber, err)
 			return result
 		}
 		result.CommentID = commentID
@@ -109,7 +119,7 @@ func run(config *Config) *Result {
 	actionskit.Info(fmt.Sprintf("Closing issue #%s", config.IssueNumber))
 	err := closeIssue(config.Repository, config.IssueNumber, config.StateReason, config.Token)
 	if err != nil {
-		result.Error = fmt.Errorf("error closing issue: %v", err)
+		result.Error = fmt.Errorf("error closing issue %s#%s: %v", config.Repository, config.IssueNumber, err)
 		return result
 	}
 
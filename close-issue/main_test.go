@@ -65,6 +65,43 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 				Token:       "test-token",
 			},
 		},
+		{
+			name: "repository input overrides GITHUB_REPOSITORY",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "ops/central")
+				os.Setenv("INPUT_REPOSITORY", "services/api")
+				os.Setenv("INPUT_ISSUE_NUMBER", "42")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:  "services/api",
+				IssueNumber: "42",
+				StateReason: "closed",
+				Token:       "test-token",
+			},
+		},
+		{
+			name: "invalid repository format",
+			setupEnv: func() {
+				os.Setenv("INPUT_REPOSITORY", "https://github.com/services/api")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    `repository must be in owner/name format, got "https://github.com/services/api"`,
+		},
 		{
 			name: "missing repository",
 			setupEnv: func() {
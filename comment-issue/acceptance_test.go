@@ -113,6 +113,90 @@ func TestAcceptanceCommentIssueLongComment(t *testing.T) {
 	}
 }
 
+func TestAcceptanceCommentIssueWithReactions(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that accepts the comment and its reactions
+	reactionCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && strings.Contains(r.URL.Path, "/issues/comments/456789/reactions") {
+			reactionCount++
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+		} else if r.Method == "POST" && strings.Contains(r.URL.Path, "/issues/123/comments") {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 456789, "body": "Deployed"}`)
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_ISSUE_NUMBER": "123",
+		"INPUT_COMMENT_BODY": "Deployed",
+		"INPUT_REACTIONS":    "+1,rocket",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if reactionCount != 2 {
+		t.Errorf("Expected 2 reactions to be added, got %d", reactionCount)
+	}
+}
+
+func TestAcceptanceCommentIssueInvalidReaction(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup environment with an unsupported reaction
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_ISSUE_NUMBER": "123",
+		"INPUT_COMMENT_BODY": "Test comment",
+		"INPUT_REACTIONS":    "party",
+		"INPUT_GITHUB_TOKEN": "test-token",
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stderr, `invalid reaction "party"`) {
+		t.Errorf("Expected stderr to contain 'invalid reaction \"party\"', got: %s", stderr)
+	}
+}
+
 func TestAcceptanceCommentIssueMissingInput(t *testing.T) {
 	// Build the binary first
 	binaryPath := buildBinary(t)
@@ -306,6 +390,129 @@ func TestAcceptanceCommentIssueNotFound(t *testing.T) {
 	}
 }
 
+func TestAcceptanceCommentIssueOtherRepository(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that only accepts comments on the target repository
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/repos/other/service/issues/7/comments" {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 8080, "body": "Cross-repo comment"}`)
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_REPOSITORY":   "other/service",
+		"INPUT_ISSUE_NUMBER": "7",
+		"INPUT_COMMENT_BODY": "Cross-repo comment",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stdout, "Comment added successfully (ID: 8080)") {
+		t.Errorf("Expected stdout to contain 'Comment added successfully (ID: 8080)', got: %s", stdout)
+	}
+}
+
+func TestAcceptanceCommentIssueOtherRepositoryForbidden(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that rejects the token, as GITHUB_TOKEN would for another repository
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_REPOSITORY":   "other/service",
+		"INPUT_ISSUE_NUMBER": "7",
+		"INPUT_COMMENT_BODY": "Cross-repo comment",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	expectedStderr := []string{"error adding comment to other/service#7", "API request failed with status 403"}
+	for _, expected := range expectedStderr {
+		if !strings.Contains(stderr, expected) {
+			t.Errorf("Expected stderr to contain %q, got: %s", expected, stderr)
+		}
+	}
+}
+
+func TestAcceptanceCommentIssueInvalidRepository(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup environment with a malformed repository input
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_REPOSITORY":   "other",
+		"INPUT_ISSUE_NUMBER": "7",
+		"INPUT_COMMENT_BODY": "Cross-repo comment",
+		"INPUT_GITHUB_TOKEN": "test-token",
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stderr, `repository must be in owner/name format, got "other"`) {
+		t.Errorf("Expected stderr to contain repository format error, got: %s", stderr)
+	}
+}
+
 // setupEnv sets environment variables and returns the old values for restoration
 func setupEnv(envVars map[string]string) map[string]string {
 	oldEnv := make(map[string]string)
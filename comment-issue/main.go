@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/half-ogre/go-kit/actionskit"
 )
@@ -15,6 +17,10 @@ type CommentRequest struct {
 	Body string `json:"body"`
 }
 
+type ReactionRequest struct {
+	Content string `json:"content"`
+}
+
 type Comment struct {
 	ID   int    `json:"id"`
 	Body string `json:"body"`
@@ -25,16 +31,24 @@ type Config struct {
 	Repository  string
 	IssueNumber string
 	CommentBody string
+	Reactions   []string
 	Token       string
 }
 
+// validReactions lists the reaction contents accepted by the GitHub reactions API
+var validReactions = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
 // Result holds the result of the comment-issue action
 type Result struct {
-	CommentID int
-	Success   bool
-	Error     error
+	CommentID       int
+	FailedReactions []string
+	Success         bool
+	Error           error
 }
 
+// repositoryPattern matches an owner/name repository reference
+var repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
 func main() {
 	config, err := getConfigFromEnvironment()
 	if err != nil {
@@ -60,10 +74,16 @@ func main() {
 
 // getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
 func getConfigFromEnvironment() (*Config, error) {
-	repository := os.Getenv("GITHUB_REPOSITORY")
+	repository := actionskit.GetInput("repository")
+	if repository == "" {
+		repository = os.Getenv("GITHUB_REPOSITORY")
+	}
 	if repository == "" {
 		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
 	}
+	if !repositoryPattern.MatchString(repository) {
+		return nil, fmt.Errorf("repository must be in owner/name format, got %q", repository)
+	}
 
 	issueNumber := actionskit.GetInput("issue-number")
 	if issueNumber == "" {
@@ -75,6 +95,11 @@ func getConfigFromEnvironment() (*Config, error) {
 		return nil, fmt.Errorf("comment-body input is required")
 	}
 
+	reactions, err := parseReactions(actionskit.GetInput("reactions"))
+	if err != nil {
+		return nil, err
+	}
+
 	token := actionskit.GetInput("github-token")
 	if token == "" {
 		return nil, fmt.Errorf("github-token input is required")
@@ -84,6 +109,7 @@ func getConfigFromEnvironment() (*Config, error) {
 		Repository:  repository,
 		IssueNumber: issueNumber,
 		CommentBody: commentBody,
+		Reactions:   reactions,
 		Token:       token,
 	}, nil
 }
@@ -95,15 +121,49 @@ func run(config *Config) *Result {
 	// Add the comment
 	commentID, err := addComment(config.Repository, config.IssueNumber, config.CommentBody, config.Token)
 	if err != nil {
-		result.Error = fmt.Errorf("error adding comment: %v", err)
+		result.Error = fmt.Errorf("error adding comment to %s#%s: %v", config.Repository, config.IssueNumber, err)
 		return result
 	}
 
 	result.CommentID = commentID
+
+	// React to the new comment. The comment already exists, so a failed reaction is
+	// only a warning; failing the step would hide comment-id and a rerun would post
+	// the comment again.
+	for _, reaction := range config.Reactions {
+		if err := addReaction(config.Repository, commentID, reaction, config.Token); err != nil {
+			actionskit.Warning(fmt.Sprintf("Failed to add %s reaction to comment %d in %s: %v", reaction, commentID, config.Repository, err))
+			result.FailedReactions = append(result.FailedReactions, reaction)
+		}
+	}
+
 	result.Success = true
 	return result
 }
 
+// parseReactions splits a comma-separated reactions input and validates each entry
+func parseReactions(reactionsInput string) ([]string, error) {
+	var reactions []string
+	for _, reaction := range strings.Split(reactionsInput, ",") {
+		reaction = strings.TrimSpace(reaction)
+		if reaction == "" {
+			continue
+		}
+		valid := false
+		for _, validReaction := range validReactions {
+			if reaction == validReaction {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid reaction %q, must be one of %s", reaction, strings.Join(validReactions, ", "))
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, nil
+}
+
 func addComment(repository, issueNumber, body, token string) (int, error) {
 	// Build API URL
 	apiBase := os.Getenv("GITHUB_API_URL")
@@ -163,4 +223,59 @@ func addComment(repository, issueNumber, body, token string) (int, error) {
 	}
 
 	return comment.ID, nil
+}
+
+func addReaction(repository string, commentID int, content, token string) error {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/issues/comments/%d/reactions", apiBase, repository, commentID)
+
+	// Create request body
+	request := ReactionRequest{
+		Content: content,
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body_bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Check status (200 means the reaction already existed)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body_bytes))
+	}
+
+	return nil
 }
\ No newline at end of file
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -156,5 +159,161 @@ And other markdown content.`
 	}
 }
 
+func TestParseReactions(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "empty input",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "single reaction",
+			input:    "rocket",
+			expected: []string{"rocket"},
+		},
+		{
+			name:     "multiple reactions with spaces",
+			input:    "+1, rocket ,eyes,",
+			expected: []string{"+1", "rocket", "eyes"},
+		},
+		{
+			name:        "invalid reaction",
+			input:       "+1,thumbsup",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reactions, err := parseReactions(tt.input)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(reactions, tt.expected) {
+				t.Errorf("parseReactions(%q) = %v, want %v", tt.input, reactions, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunWithReactions(t *testing.T) {
+	var reactions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/issues/comments/789012/reactions") {
+			var request ReactionRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			reactions = append(reactions, request.Content)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 789012, "body": "Build passed"}`)
+	}))
+	defer server.Close()
+
+	os.Setenv("GITHUB_API_URL", server.URL)
+	defer os.Unsetenv("GITHUB_API_URL")
+
+	result := run(&Config{
+		Repository:  "test/repo",
+		IssueNumber: "456",
+		CommentBody: "Build passed",
+		Reactions:   []string{"+1", "rocket"},
+		Token:       "test-token",
+	})
+
+	if result.Error != nil {
+		t.Fatalf("Unexpected error: %v", result.Error)
+	}
+	if result.CommentID != 789012 {
+		t.Errorf("Expected comment ID 789012, got %d", result.CommentID)
+	}
+	if !reflect.DeepEqual(reactions, []string{"+1", "rocket"}) {
+		t.Errorf("Reactions = %v, want [+1 rocket]", reactions)
+	}
+}
+
+func TestRunReactionFailure(t *testing.T) {
+	var reactions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/issues/comments/789012/reactions") {
+			var request ReactionRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			if request.Content == "rocket" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"message": "Forbidden"}`)
+				return
+			}
+			reactions = append(reactions, request.Content)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 789012, "body": "Build passed"}`)
+	}))
+	defer server.Close()
+
+	os.Setenv("GITHUB_API_URL", server.URL)
+	defer os.Unsetenv("GITHUB_API_URL")
+
+	result := run(&Config{
+		Repository:  "test/repo",
+		IssueNumber: "456",
+		CommentBody: "Build passed",
+		Reactions:   []string{"rocket", "+1"},
+		Token:       "test-token",
+	})
+
+	// The comment was posted, so the failed reaction does not fail the run
+	if result.Error != nil {
+		t.Fatalf("Unexpected error: %v", result.Error)
+	}
+	if !result.Success {
+		t.Error("Expected Success to be true")
+	}
+	if result.CommentID != 789012 {
+		t.Errorf("Expected comment ID 789012, got %d", result.CommentID)
+	}
+	if !reflect.DeepEqual(result.FailedReactions, []string{"rocket"}) {
+		t.Errorf("FailedReactions = %v, want [rocket]", result.FailedReactions)
+	}
+	if !reflect.DeepEqual(reactions, []string{"+1"}) {
+		t.Errorf("Reactions = %v, want [+1]", reactions)
+	}
+}
+
+func TestAddReactionAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Forbidden"}`)
+	}))
+	defer server.Close()
+
+	os.Setenv("GITHUB_API_URL", server.URL)
+	defer os.Unsetenv("GITHUB_API_URL")
+
+	err := addReaction("test/repo", 123, "eyes", "test-token")
+	if err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("Expected status 403 error, got %v", err)
+	}
+}
+
 // TestMain is omitted because testing functions that call os.Exit is complex
 // In production code, we'd refactor main() to return an error instead of exiting
\ No newline at end of file
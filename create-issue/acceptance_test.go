@@ -230,6 +230,77 @@ func TestAcceptanceCreateIssueValidationError(t *testing.T) {
 	}
 }
 
+func TestAcceptanceCreateIssueFanOutPartialFailure(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server where one repository rejects the token
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/repos/org/locked/"):
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+		case r.Method == "GET" && r.URL.Path == "/repos/org/existing/issues":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `[{"number": 17, "title": "Shared dependency broke", "body": "<!-- create-issue-fingerprint: libfoo -->"}]`)
+		case r.Method == "GET":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `[]`)
+		case r.Method == "POST" && r.URL.Path == "/repos/org/api/issues":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"number": 42, "title": "Shared dependency broke"}`)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"INPUT_REPOSITORIES": "org/api,org/existing,org/locked",
+		"INPUT_FINGERPRINT":  "libfoo",
+		"INPUT_ISSUE_TITLE":  "Shared dependency broke",
+		"INPUT_ISSUE_LABEL":  "dependencies",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	expectedStdout := []string{
+		"Created new issue #42 in org/api",
+		"Skipped org/existing: issue #17 already has fingerprint libfoo",
+		`issues-json::{"org/api":42,"org/existing":17}`,
+		"failed-json::{\"org/locked\":",
+	}
+	for _, expected := range expectedStdout {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected stdout to contain %q, got: %s", expected, stdout)
+		}
+	}
+
+	expectedStderr := []string{"org/locked: error checking for existing issue", "Failed to create issues in 1 of 3 repositories"}
+	for _, expected := range expectedStderr {
+		if !strings.Contains(stderr, expected) {
+			t.Errorf("Expected stderr to contain %q, got: %s", expected, stderr)
+		}
+	}
+}
+
 // setupEnv sets environment variables and returns the old values for restoration
 func setupEnv(envVars map[string]string) map[string]string {
 	oldEnv := make(map[string]string)
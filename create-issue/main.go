@@ -2,12 +2,18 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/half-ogre/go-kit/actionskit"
 )
@@ -21,25 +27,51 @@ type CreateIssueRequest struct {
 type Issue struct {
 	Number int    `json:"number"`
 	Title  string `json:"title"`
+	Body   string `json:"body"`
 }
 
 // Config holds the configuration for the create-issue action
 type Config struct {
 	Repository       string
+	Repositories     []string
 	Title            string
 	Body             string
 	PrimaryLabel     string
 	AdditionalLabels string
+	Fingerprint      string
+	MaxConcurrency   int
 	Token            string
 }
 
-// Result holds the result of the create-issue action
-type Result struct {
+// RepositoryResult holds the outcome for one repository when fanning out
+type RepositoryResult struct {
+	Repository  string
 	IssueNumber int
-	Success     bool
+	Existing    bool
 	Error       error
 }
 
+// Result holds the result of the create-issue action
+type Result struct {
+	IssueNumber  int
+	Existing     bool
+	Repositories []RepositoryResult
+	Success      bool
+	Error        error
+}
+
+// defaultMaxConcurrency is the number of repositories processed at once when fanning out
+const defaultMaxConcurrency = 4
+
+// issuesPerPage is the page size used when listing issues to check fingerprints
+const issuesPerPage = 100
+
+// repositoryPattern matches an owner/name repository reference
+var repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+// fingerprintPattern restricts fingerprints to text that is safe inside an HTML comment
+var fingerprintPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
 func main() {
 	config, err := getConfigFromEnvironment()
 	if err != nil {
@@ -53,22 +85,88 @@ func main() {
 		os.Exit(1)
 	}
 
-	actionskit.Info(fmt.Sprintf("Created new issue #%d", result.IssueNumber))
+	if len(config.Repositories) == 0 {
+		if result.Existing {
+			actionskit.Info(fmt.Sprintf("Skipped: issue #%d already has fingerprint %s", result.IssueNumber, config.Fingerprint))
+		} else {
+			actionskit.Info(fmt.Sprintf("Created new issue #%d", result.IssueNumber))
+		}
+
+		// Set output for GitHub Actions
+		err = actionskit.SetOutput("issue-number", fmt.Sprintf("%d", result.IssueNumber))
+		if err != nil {
+			actionskit.Error(fmt.Sprintf("Failed to set output: %v", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Report each repository and collect the fan-out outputs
+	issues := map[string]int{}
+	failures := map[string]string{}
+	for _, repoResult := range result.Repositories {
+		switch {
+		case repoResult.Error != nil:
+			failures[repoResult.Repository] = repoResult.Error.Error()
+			actionskit.Error(fmt.Sprintf("%s: %v", repoResult.Repository, repoResult.Error))
+		case repoResult.Existing:
+			issues[repoResult.Repository] = repoResult.IssueNumber
+			actionskit.Info(fmt.Sprintf("Skipped %s: issue #%d already has fingerprint %s",
+				repoResult.Repository, repoResult.IssueNumber, config.Fingerprint))
+		default:
+			issues[repoResult.Repository] = repoResult.IssueNumber
+			actionskit.Info(fmt.Sprintf("Created new issue #%d in %s", repoResult.IssueNumber, repoResult.Repository))
+		}
+	}
+
+	issuesJSON, err := json.Marshal(issues)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to encode issues-json: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("issues-json", string(issuesJSON))
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set issues-json output: %v", err))
+		os.Exit(1)
+	}
 
-	// Set output for GitHub Actions
-	err = actionskit.SetOutput("issue-number", fmt.Sprintf("%d", result.IssueNumber))
+	failedJSON, err := json.Marshal(failures)
 	if err != nil {
-		actionskit.Error(fmt.Sprintf("Failed to set output: %v", err))
+		actionskit.Error(fmt.Sprintf("Failed to encode failed-json: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("failed-json", string(failedJSON))
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set failed-json output: %v", err))
+		os.Exit(1)
+	}
+
+	if len(failures) > 0 {
+		actionskit.Error(fmt.Sprintf("Failed to create issues in %d of %d repositories",
+			len(failures), len(result.Repositories)))
 		os.Exit(1)
 	}
 }
 
 // getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
 func getConfigFromEnvironment() (*Config, error) {
-	repository := os.Getenv("GITHUB_REPOSITORY")
+	repositories, err := getRepositories(actionskit.GetInput("repositories"), actionskit.GetInput("repositories-file"))
+	if err != nil {
+		return nil, err
+	}
+
+	repository := actionskit.GetInput("repository")
 	if repository == "" {
+		repository = os.Getenv("GITHUB_REPOSITORY")
+	}
+	if repository == "" && len(repositories) == 0 {
 		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
 	}
+	if repository != "" && !repositoryPattern.MatchString(repository) {
+		return nil, fmt.Errorf("repository must be in owner/name format, got %q", repository)
+	}
 
 	title := actionskit.GetInput("issue-title")
 	if title == "" {
@@ -82,6 +180,24 @@ func getConfigFromEnvironment() (*Config, error) {
 	}
 
 	additionalLabels := actionskit.GetInput("additional-labels")
+
+	// Fan-out always deduplicates, so fall back to a fingerprint derived from the title
+	fingerprint := actionskit.GetInput("fingerprint")
+	if fingerprint == "" && len(repositories) > 0 {
+		fingerprint = titleFingerprint(title)
+	}
+	if fingerprint != "" && !fingerprintPattern.MatchString(fingerprint) {
+		return nil, fmt.Errorf("fingerprint may only contain letters, digits, '.', '_' and '-', got %q", fingerprint)
+	}
+
+	maxConcurrency := defaultMaxConcurrency
+	if maxConcurrencyInput := actionskit.GetInput("max-concurrency"); maxConcurrencyInput != "" {
+		maxConcurrency, err = strconv.Atoi(maxConcurrencyInput)
+		if err != nil || maxConcurrency < 1 {
+			return nil, fmt.Errorf("max-concurrency must be a positive integer, got %q", maxConcurrencyInput)
+		}
+	}
+
 	token := actionskit.GetInput("github-token")
 	if token == "" {
 		return nil, fmt.Errorf("github-token input is required")
@@ -89,25 +205,101 @@ func getConfigFromEnvironment() (*Config, error) {
 
 	return &Config{
 		Repository:       repository,
+		Repositories:     repositories,
 		Title:            title,
 		Body:             body,
 		PrimaryLabel:     primaryLabel,
 		AdditionalLabels: additionalLabels,
+		Fingerprint:      fingerprint,
+		MaxConcurrency:   maxConcurrency,
 		Token:            token,
 	}, nil
 }
 
+// getRepositories combines the repositories input and the lines of the repositories
+// file into a de-duplicated list, ignoring blank lines and # comments
+func getRepositories(repositoriesInput, repositoriesFile string) ([]string, error) {
+	entries := strings.FieldsFunc(repositoriesInput, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+
+	if repositoriesFile != "" {
+		data, err := os.ReadFile(repositoriesFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading repositories-file: %v", err)
+		}
+		entries = append(entries, strings.Split(string(data), "\n")...)
+	}
+
+	var repositories []string
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		if !repositoryPattern.MatchString(entry) {
+			return nil, fmt.Errorf("invalid repository %q in repositories, must be in owner/name format", entry)
+		}
+		key := strings.ToLower(entry)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		repositories = append(repositories, entry)
+	}
+
+	return repositories, nil
+}
+
+// titleFingerprint derives a stable fingerprint from an issue title
+func titleFingerprint(title string) string {
+	sum := sha256.Sum256([]byte(title))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// fingerprintMarker returns the hidden HTML comment that tags an issue with a fingerprint
+func fingerprintMarker(fingerprint string) string {
+	return fmt.Sprintf("<!-- create-issue-fingerprint: %s -->", fingerprint)
+}
+
+// appendFingerprintMarker ends an issue body with the fingerprint marker
+func appendFingerprintMarker(body, fingerprint string) string {
+	return strings.TrimRight(body, "\n") + "\n\n" + fingerprintMarker(fingerprint)
+}
+
 // run executes the create-issue action with the given configuration
 func run(config *Config) *Result {
+	if len(config.Repositories) > 0 {
+		return runFanOut(config)
+	}
+
 	result := &Result{Success: false}
 
 	// Build labels array
 	labels := buildLabels(config.PrimaryLabel, config.AdditionalLabels)
 
+	// Reuse an open issue that already carries the fingerprint
+	body := config.Body
+	if config.Fingerprint != "" {
+		existing, err := findIssueByFingerprint(config.Repository, config.PrimaryLabel, config.Fingerprint, config.Token)
+		if err != nil {
+			result.Error = fmt.Errorf("error checking for existing issue in %s: %v", config.Repository, err)
+			return result
+		}
+		if existing != 0 {
+			result.IssueNumber = existing
+			result.Existing = true
+			result.Success = true
+			return result
+		}
+		body = appendFingerprintMarker(body, config.Fingerprint)
+	}
+
 	// Create the issue
-	issueNumber, err := createIssue(config.Repository, config.Title, config.Body, labels, config.Token)
+	issueNumber, err := createIssue(config.Repository, config.Title, body, labels, config.Token)
 	if err != nil {
-		result.Error = fmt.Errorf("error creating issue: %v", err)
+		result.Error = fmt.Errorf("error creating issue in %s: %v", config.Repository, err)
 		return result
 	}
 
@@ -116,6 +308,78 @@ func run(config *Config) *Result {
 	return result
 }
 
+// runFanOut creates the issue in every configured repository using a bounded pool of
+// workers. Failures are recorded per repository rather than stopping the others.
+func runFanOut(config *Config) *Result {
+	result := &Result{Success: false}
+
+	labels := buildLabels(config.PrimaryLabel, config.AdditionalLabels)
+	body := config.Body
+	if config.Fingerprint != "" {
+		body = appendFingerprintMarker(body, config.Fingerprint)
+	}
+
+	workers := config.MaxConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Each worker writes only to its own index, so results keep the input order
+	result.Repositories = make([]RepositoryResult, len(config.Repositories))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result.Repositories[i] = createIssueInRepository(config, config.Repositories[i], body, labels)
+			}
+		}()
+	}
+	for i := range config.Repositories {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	result.Success = true
+	for _, repoResult := range result.Repositories {
+		if repoResult.Error != nil {
+			result.Success = false
+		}
+	}
+	return result
+}
+
+// createIssueInRepository creates the issue in one repository unless an open issue
+// already carries the configured fingerprint
+func createIssueInRepository(config *Config, repository, body string, labels []string) RepositoryResult {
+	repoResult := RepositoryResult{Repository: repository}
+
+	if config.Fingerprint != "" {
+		existing, err := findIssueByFingerprint(repository, config.PrimaryLabel, config.Fingerprint, config.Token)
+		if err != nil {
+			repoResult.Error = fmt.Errorf("error checking for existing issue: %v", err)
+			return repoResult
+		}
+		if existing != 0 {
+			repoResult.IssueNumber = existing
+			repoResult.Existing = true
+			return repoResult
+		}
+	}
+
+	issueNumber, err := createIssue(repository, config.Title, body, labels, config.Token)
+	if err != nil {
+		repoResult.Error = fmt.Errorf("error creating issue: %v", err)
+		return repoResult
+	}
+
+	repoResult.IssueNumber = issueNumber
+	return repoResult
+}
+
 // buildLabels constructs the labels array from primary and additional labels
 func buildLabels(primaryLabel, additionalLabels string) []string {
 	var labels []string
@@ -195,4 +459,71 @@ func createIssue(repository, title, body string, labels []string, token string)
 	}
 
 	return issue.Number, nil
-}
\ No newline at end of file
+}
+
+// findIssueByFingerprint returns the number of an open issue with the label whose body
+// contains the fingerprint marker, or 0 if there is none
+func findIssueByFingerprint(repository, label, fingerprint, token string) (int, error) {
+	marker := fingerprintMarker(fingerprint)
+	for page := 1; ; page++ {
+		issues, err := listIssuesPage(repository, label, page, token)
+		if err != nil {
+			return 0, err
+		}
+		for _, issue := range issues {
+			if strings.Contains(issue.Body, marker) {
+				return issue.Number, nil
+			}
+		}
+		if len(issues) < issuesPerPage {
+			return 0, nil
+		}
+	}
+}
+
+func listIssuesPage(repository, label string, page int, token string) ([]Issue, error) {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	labelParam := url.QueryEscape(label)
+	url := fmt.Sprintf("%s/repos/%s/issues?state=open&labels=%s&per_page=%d&page=%d",
+		apiBase, repository, labelParam, issuesPerPage, page)
+
+	// Create request
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Check status
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var issues []Issue
+	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestGetConfigFromEnvironment(t *testing.T) {
@@ -70,6 +75,47 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 				Token:            "test-token",
 			},
 		},
+		{
+			name: "repository input overrides GITHUB_REPOSITORY",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "ops/central")
+				os.Setenv("INPUT_REPOSITORY", "services/api")
+				os.Setenv("INPUT_ISSUE_TITLE", "Dependency broke")
+				os.Setenv("INPUT_ISSUE_LABEL", "dependencies")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_TITLE")
+				os.Unsetenv("INPUT_ISSUE_LABEL")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:   "services/api",
+				Title:        "Dependency broke",
+				PrimaryLabel: "dependencies",
+				Token:        "test-token",
+			},
+		},
+		{
+			name: "invalid repository format",
+			setupEnv: func() {
+				os.Setenv("INPUT_REPOSITORY", "services-api")
+				os.Setenv("INPUT_ISSUE_TITLE", "Test Issue")
+				os.Setenv("INPUT_ISSUE_LABEL", "test")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_TITLE")
+				os.Unsetenv("INPUT_ISSUE_LABEL")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    `repository must be in owner/name format, got "services-api"`,
+		},
 		{
 			name: "missing repository",
 			setupEnv: func() {
@@ -175,6 +221,177 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 	}
 }
 
+func TestGetConfigFromEnvironmentFanOut(t *testing.T) {
+	tests := []struct {
+		name                 string
+		env                  map[string]string
+		expectError          bool
+		errorMsg             string
+		expectedRepositories []string
+		expectedFingerprint  string
+		expectedConcurrency  int
+	}{
+		{
+			name: "repositories without GITHUB_REPOSITORY",
+			env: map[string]string{
+				"INPUT_REPOSITORIES": "org/api, org/web\norg/worker",
+			},
+			expectedRepositories: []string{"org/api", "org/web", "org/worker"},
+			expectedFingerprint:  titleFingerprint("Shared dependency broke"),
+			expectedConcurrency:  4,
+		},
+		{
+			name: "explicit fingerprint and concurrency",
+			env: map[string]string{
+				"INPUT_REPOSITORIES":    "org/api",
+				"INPUT_FINGERPRINT":     "libfoo-2.3.1",
+				"INPUT_MAX_CONCURRENCY": "2",
+			},
+			expectedRepositories: []string{"org/api"},
+			expectedFingerprint:  "libfoo-2.3.1",
+			expectedConcurrency:  2,
+		},
+		{
+			name: "invalid repository in list",
+			env: map[string]string{
+				"INPUT_REPOSITORIES": "org/api,not-a-repo",
+			},
+			expectError: true,
+			errorMsg:    `invalid repository "not-a-repo" in repositories, must be in owner/name format`,
+		},
+		{
+			name: "invalid fingerprint",
+			env: map[string]string{
+				"INPUT_REPOSITORIES": "org/api",
+				"INPUT_FINGERPRINT":  "a -->",
+			},
+			expectError: true,
+			errorMsg:    `fingerprint may only contain letters, digits, '.', '_' and '-', got "a -->"`,
+		},
+		{
+			name: "invalid max concurrency",
+			env: map[string]string{
+				"INPUT_REPOSITORIES":    "org/api",
+				"INPUT_MAX_CONCURRENCY": "0",
+			},
+			expectError: true,
+			errorMsg:    `max-concurrency must be a positive integer, got "0"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{
+				"INPUT_ISSUE_TITLE":  "Shared dependency broke",
+				"INPUT_ISSUE_LABEL":  "dependencies",
+				"INPUT_GITHUB_TOKEN": "test-token",
+			}
+			for key, value := range tt.env {
+				env[key] = value
+			}
+			for key, value := range env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			config, err := getConfigFromEnvironment()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				} else if err.Error() != tt.errorMsg {
+					t.Errorf("Expected error message %q, got %q", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if strings.Join(config.Repositories, ",") != strings.Join(tt.expectedRepositories, ",") {
+				t.Errorf("Repositories = %v, want %v", config.Repositories, tt.expectedRepositories)
+			}
+			if config.Fingerprint != tt.expectedFingerprint {
+				t.Errorf("Fingerprint = %q, want %q", config.Fingerprint, tt.expectedFingerprint)
+			}
+			if config.MaxConcurrency != tt.expectedConcurrency {
+				t.Errorf("MaxConcurrency = %d, want %d", config.MaxConcurrency, tt.expectedConcurrency)
+			}
+		})
+	}
+}
+
+func TestGetRepositories(t *testing.T) {
+	tempDir := t.TempDir()
+	repositoriesFile := filepath.Join(tempDir, "repos.txt")
+	content := "# services\norg/api\n\norg/billing\nORG/API\n"
+	if err := os.WriteFile(repositoriesFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write repositories file: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		repositoriesList string
+		repositoriesFile string
+		expected         []string
+		expectError      bool
+	}{
+		{
+			name:             "empty",
+			repositoriesList: "",
+			expected:         nil,
+		},
+		{
+			name:             "comma and newline separated",
+			repositoriesList: "org/a,org/b\n org/c ",
+			expected:         []string{"org/a", "org/b", "org/c"},
+		},
+		{
+			name:             "file with comments and case-insensitive duplicates",
+			repositoriesFile: repositoriesFile,
+			expected:         []string{"org/api", "org/billing"},
+		},
+		{
+			name:             "list and file combined",
+			repositoriesList: "org/web,org/api",
+			repositoriesFile: repositoriesFile,
+			expected:         []string{"org/web", "org/api", "org/billing"},
+		},
+		{
+			name:             "missing file",
+			repositoriesFile: filepath.Join(tempDir, "missing.txt"),
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getRepositories(tt.repositoriesList, tt.repositoriesFile)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("getRepositories() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestBuildLabels(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -479,4 +696,202 @@ func TestCreateIssueEmptyLabels(t *testing.T) {
 	if issueNumber != 456 {
 		t.Errorf("Expected issue number 456, got %d", issueNumber)
 	}
-}
\ No newline at end of file
+}
+
+func TestRunFanOut(t *testing.T) {
+	fingerprint := "libfoo-2.3.1"
+	marker := fingerprintMarker(fingerprint)
+
+	var mu sync.Mutex
+	created := map[string]string{}
+	inFlight, maxInFlight := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		repository := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/"), "/issues")
+		switch {
+		case repository == "org/locked":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+		case r.Method == "GET":
+			if r.URL.Query().Get("labels") != "dependencies" {
+				t.Errorf("Expected labels=dependencies, got %q", r.URL.Query().Get("labels"))
+			}
+			w.WriteHeader(http.StatusOK)
+			if repository == "org/existing" {
+				fmt.Fprintf(w, `[{"number": 9, "title": "Other", "body": "unrelated"}, {"number": 17, "title": "Broke", "body": %q}]`,
+					"Details\n\n"+marker)
+			} else {
+				fmt.Fprint(w, `[]`)
+			}
+		case r.Method == "POST":
+			var request CreateIssueRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			mu.Lock()
+			created[repository] = request.Body
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"number": %d, "title": %q}`, 100+len(repository), request.Title)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("GITHUB_API_URL", server.URL)
+	defer os.Unsetenv("GITHUB_API_URL")
+
+	config := &Config{
+		Repositories:   []string{"org/api", "org/existing", "org/locked", "org/web", "org/worker"},
+		Title:          "Shared dependency broke",
+		Body:           "Details",
+		PrimaryLabel:   "dependencies",
+		Fingerprint:    fingerprint,
+		MaxConcurrency: 2,
+		Token:          "test-token",
+	}
+
+	result := run(config)
+
+	if result.Error != nil {
+		t.Fatalf("Unexpected error: %v", result.Error)
+	}
+	if result.Success {
+		t.Error("Expected Success to be false when a repository fails")
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+
+	if len(result.Repositories) != len(config.Repositories) {
+		t.Fatalf("Expected %d repository results, got %d", len(config.Repositories), len(result.Repositories))
+	}
+	for i, repoResult := range result.Repositories {
+		if repoResult.Repository != config.Repositories[i] {
+			t.Errorf("Repositories[%d] = %q, want %q", i, repoResult.Repository, config.Repositories[i])
+		}
+	}
+
+	existing := result.Repositories[1]
+	if !existing.Existing || existing.IssueNumber != 17 || existing.Error != nil {
+		t.Errorf("Expected org/existing to be skipped with issue 17, got %+v", existing)
+	}
+	if _, ok := created["org/existing"]; ok {
+		t.Error("Expected no issue to be created in org/existing")
+	}
+
+	locked := result.Repositories[2]
+	if locked.Error == nil || !strings.Contains(locked.Error.Error(), "status 403") {
+		t.Errorf("Expected org/locked to fail with status 403, got %+v", locked)
+	}
+
+	for _, i := range []int{0, 3, 4} {
+		repoResult := result.Repositories[i]
+		if repoResult.Error != nil || repoResult.Existing || repoResult.IssueNumber != 100+len(repoResult.Repository) {
+			t.Errorf("Expected %s to be created, got %+v", repoResult.Repository, repoResult)
+		}
+		if body := created[repoResult.Repository]; body != "Details\n\n"+marker {
+			t.Errorf("Expected body with fingerprint marker in %s, got %q", repoResult.Repository, body)
+		}
+	}
+}
+
+func TestRunFingerprint(t *testing.T) {
+	fingerprint := "libfoo-2.3.1"
+	marker := fingerprintMarker(fingerprint)
+
+	tests := []struct {
+		name                string
+		issues              string
+		expectedIssueNumber int
+		expectedExisting    bool
+		expectedBody        string
+	}{
+		{
+			name:                "creates the issue with the fingerprint marker",
+			issues:              `[{"number": 9, "title": "Other", "body": "unrelated"}]`,
+			expectedIssueNumber: 123,
+			expectedBody:        "Details\n\n" + marker,
+		},
+		{
+			name:                "reuses an open issue with the fingerprint",
+			issues:              fmt.Sprintf(`[{"number": 17, "title": "Broke", "body": %q}]`, "Details\n\n"+marker),
+			expectedIssueNumber: 17,
+			expectedExisting:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *CreateIssueRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/test/repo/issues" {
+					t.Errorf("Expected path /repos/test/repo/issues, got %s", r.URL.Path)
+				}
+				switch r.Method {
+				case "GET":
+					if r.URL.Query().Get("labels") != "dependencies" {
+						t.Errorf("Expected labels=dependencies, got %q", r.URL.Query().Get("labels"))
+					}
+					w.WriteHeader(http.StatusOK)
+					fmt.Fprint(w, tt.issues)
+				case "POST":
+					created = &CreateIssueRequest{}
+					if err := json.NewDecoder(r.Body).Decode(created); err != nil {
+						t.Errorf("Failed to decode request: %v", err)
+					}
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"number": 123, "title": "Shared dependency broke"}`)
+				}
+			}))
+			defer server.Close()
+
+			os.Setenv("GITHUB_API_URL", server.URL)
+			defer os.Unsetenv("GITHUB_API_URL")
+
+			result := run(&Config{
+				Repository:   "test/repo",
+				Title:        "Shared dependency broke",
+				Body:         "Details",
+				PrimaryLabel: "dependencies",
+				Fingerprint:  fingerprint,
+				Token:        "test-token",
+			})
+
+			if result.Error != nil {
+				t.Fatalf("Unexpected error: %v", result.Error)
+			}
+			if !result.Success {
+				t.Error("Expected Success to be true")
+			}
+			if result.IssueNumber != tt.expectedIssueNumber {
+				t.Errorf("IssueNumber = %d, want %d", result.IssueNumber, tt.expectedIssueNumber)
+			}
+			if result.Existing != tt.expectedExisting {
+				t.Errorf("Existing = %v, want %v", result.Existing, tt.expectedExisting)
+			}
+
+			if tt.expectedExisting {
+				if created != nil {
+					t.Errorf("Expected no issue to be created, got %+v", created)
+				}
+				return
+			}
+			if created == nil || created.Body != tt.expectedBody {
+				t.Errorf("Expected body %q, got %+v", tt.expectedBody, created)
+			}
+		})
+	}
+}
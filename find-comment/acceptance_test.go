@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAcceptanceFindCommentByMarker(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that returns comments with two marked build comments
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.Contains(r.URL.Path, "/issues/123/comments") {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `[
+				{"id": 11, "body": "<!-- build-status --> Build 1 passed", "user": {"login": "github-actions[bot]"}, "created_at": "2025-01-01T00:00:00Z"},
+				{"id": 12, "body": "Thanks!", "user": {"login": "alice"}, "created_at": "2025-01-02T00:00:00Z"},
+				{"id": 13, "body": "<!-- build-status --> Build 2 failed", "user": {"login": "github-actions[bot]"}, "created_at": "2025-01-03T00:00:00Z"}
+			]`)
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_ISSUE_NUMBER": "123",
+		"INPUT_MARKER":       "build-status",
+		"INPUT_SELECTION":    "last",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	expectedStdout := []string{
+		"Found comment 13",
+		"::set-output name=comment-id::13",
+		"::set-output name=comment-created-at::2025-01-03T00:00:00Z",
+		"::set-output name=comment-exists::true",
+	}
+	for _, expected := range expectedStdout {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected stdout to contain %q, got: %s", expected, stdout)
+		}
+	}
+
+	if stderr != "" {
+		t.Errorf("Expected empty stderr, got: %s", stderr)
+	}
+}
+
+func TestAcceptanceFindCommentNotFound(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that returns no comments
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_ISSUE_NUMBER": "123",
+		"INPUT_AUTHOR":       "alice",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stdout, "No matching comment found") {
+		t.Errorf("Expected stdout to contain 'No matching comment found', got: %s", stdout)
+	}
+	if !strings.Contains(stdout, "::set-output name=comment-exists::false") {
+		t.Errorf("Expected stdout to contain comment-exists=false, got: %s", stdout)
+	}
+}
+
+func TestAcceptanceFindCommentMissingInput(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup environment with missing issue number
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		// Missing INPUT_ISSUE_NUMBER
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stderr, "issue-number input is required") {
+		t.Errorf("Expected stderr to contain 'issue-number input is required', got: %s", stderr)
+	}
+}
+
+func TestAcceptanceFindCommentAPIError(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that returns unauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message": "Bad credentials"}`)
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_ISSUE_NUMBER": "123",
+		"INPUT_GITHUB_TOKEN": "invalid-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	expectedStderr := []string{"error listing comments", "API request failed with status 401"}
+	for _, expected := range expectedStderr {
+		if !strings.Contains(stderr, expected) {
+			t.Errorf("Expected stderr to contain %q, got: %s", expected, stderr)
+		}
+	}
+}
+
+// setupEnv sets environment variables and returns the old values for restoration
+func setupEnv(envVars map[string]string) map[string]string {
+	oldEnv := make(map[string]string)
+	for key, value := range envVars {
+		oldEnv[key] = os.Getenv(key)
+		os.Setenv(key, value)
+	}
+	return oldEnv
+}
+
+// restoreEnv restores environment variables to their previous values
+func restoreEnv(oldEnv map[string]string) {
+	for key, value := range oldEnv {
+		if value == "" {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, value)
+		}
+	}
+}
+
+// buildBinary builds the find-comment binary and returns its path
+func buildBinary(t *testing.T) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	binaryPath := filepath.Join(tempDir, "find-comment")
+
+	cmd := exec.Command("go", "build", "-o", binaryPath, "main.go")
+	cmd.Dir = "." // Current directory should be find-comment/
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Failed to build binary: %v\nOutput: %s", err, output)
+	}
+
+	return binaryPath
+}
+
+// runCommand executes a command and returns stdout, stderr, and exit code
+func runCommand(cmd *exec.Cmd) (stdout, stderr string, exitCode int) {
+	stdoutBytes, stderrBytes, err := runCommandBytes(cmd)
+	stdout = string(stdoutBytes)
+	stderr = string(stderrBytes)
+
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			exitCode = exitError.ExitCode()
+		} else {
+			exitCode = -1 // Some other error
+		}
+	} else {
+		exitCode = 0
+	}
+
+	return stdout, stderr, exitCode
+}
+
+// runCommandBytes executes a command and returns stdout and stderr as bytes
+func runCommandBytes(cmd *exec.Cmd) (stdout, stderr []byte, err error) {
+	stdoutBuf := &strings.Builder{}
+	stderrBuf := &strings.Builder{}
+
+	cmd.Stdout = stdoutBuf
+	cmd.Stderr = stderrBuf
+
+	err = cmd.Run()
+	stdout = []byte(stdoutBuf.String())
+	stderr = []byte(stderrBuf.String())
+
+	return stdout, stderr, err
+}
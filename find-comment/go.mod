@@ -0,0 +1,5 @@
+module github.com/half-ogre-games/hog-actions/find-comment
+
+go 1.24.3
+
+require github.com/half-ogre/go-kit v0.2.0
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/half-ogre/go-kit/actionskit"
+)
+
+// commentsPerPage is the page size used when listing issue comments
+const commentsPerPage = 100
+
+type User struct {
+	Login string `json:"login"`
+}
+
+type Comment struct {
+	ID        int    `json:"id"`
+	Body      string `json:"body"`
+	User      User   `json:"user"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Config holds the configuration for the find-comment action
+type Config struct {
+	Repository   string
+	IssueNumber  string
+	Author       string
+	BodyContains string
+	BodyRegex    string
+	Marker       string
+	Selection    string
+	Token        string
+}
+
+// Result holds the result of the find-comment action
+type Result struct {
+	CommentID     int
+	CommentBody   string
+	CreatedAt     string
+	CommentExists bool
+	Success       bool
+	Error         error
+}
+
+// repositoryPattern matches an owner/name repository reference
+var repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+func main() {
+	config, err := getConfigFromEnvironment()
+	if err != nil {
+		actionskit.Error(err.Error())
+		os.Exit(1)
+	}
+
+	result := run(config)
+	if result.Error != nil {
+		actionskit.Error(result.Error.Error())
+		os.Exit(1)
+	}
+
+	// Output results
+	if result.CommentExists {
+		actionskit.Info(fmt.Sprintf("Found comment %d", result.CommentID))
+	} else {
+		actionskit.Info("No matching comment found")
+	}
+
+	// Set outputs for GitHub Actions
+	commentID := ""
+	if result.CommentExists {
+		commentID = fmt.Sprintf("%d", result.CommentID)
+	}
+
+	err = actionskit.SetOutput("comment-id", commentID)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set comment-id output: %v", err))
+		os.Exit(1)
+	}
+
+	err = setMultilineOutput("comment-body", result.CommentBody)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set comment-body output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("comment-created-at", result.CreatedAt)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set comment-created-at output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("comment-exists", fmt.Sprintf("%t", result.CommentExists))
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set comment-exists output: %v", err))
+		os.Exit(1)
+	}
+}
+
+// getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
+func getConfigFromEnvironment() (*Config, error) {
+	repository := actionskit.GetInput("repository")
+	if repository == "" {
+		repository = os.Getenv("GITHUB_REPOSITORY")
+	}
+	if repository == "" {
+		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
+	}
+	if !repositoryPattern.MatchString(repository) {
+		return nil, fmt.Errorf("repository must be in owner/name format, got %q", repository)
+	}
+
+	issueNumber := actionskit.GetInput("issue-number")
+	if issueNumber == "" {
+		return nil, fmt.Errorf("issue-number input is required")
+	}
+
+	bodyRegex := actionskit.GetInput("body-regex")
+	if bodyRegex != "" {
+		if _, err := regexp.Compile(bodyRegex); err != nil {
+			return nil, fmt.Errorf("body-regex is not a valid regular expression: %v", err)
+		}
+	}
+
+	selection := strings.ToLower(actionskit.GetInput("selection"))
+	if selection == "" {
+		selection = "first"
+	}
+	if selection != "first" && selection != "last" {
+		return nil, fmt.Errorf("selection must be first or last, got %q", selection)
+	}
+
+	token := actionskit.GetInput("github-token")
+	if token == "" {
+		return nil, fmt.Errorf("github-token input is required")
+	}
+
+	return &Config{
+		Repository:   repository,
+		IssueNumber:  issueNumber,
+		Author:       actionskit.GetInput("author"),
+		BodyContains: actionskit.GetInput("body-contains"),
+		BodyRegex:    bodyRegex,
+		Marker:       actionskit.GetInput("marker"),
+		Selection:    selection,
+		Token:        token,
+	}, nil
+}
+
+// run executes the find-comment action with the given configuration
+func run(config *Config) *Result {
+	result := &Result{Success: false}
+
+	var bodyPattern *regexp.Regexp
+	if config.BodyRegex != "" {
+		pattern, err := regexp.Compile(config.BodyRegex)
+		if err != nil {
+			result.Error = fmt.Errorf("error compiling body-regex: %v", err)
+			return result
+		}
+		bodyPattern = pattern
+	}
+
+	comments, err := listComments(config.Repository, config.IssueNumber, config.Token)
+	if err != nil {
+		result.Error = fmt.Errorf("error listing comments on %s#%s: %v", config.Repository, config.IssueNumber, err)
+		return result
+	}
+
+	var matches []Comment
+	for _, comment := range comments {
+		if matchesComment(comment, config, bodyPattern) {
+			matches = append(matches, comment)
+		}
+	}
+
+	if len(matches) > 0 {
+		match := matches[0]
+		if config.Selection == "last" {
+			match = matches[len(matches)-1]
+		}
+		result.CommentID = match.ID
+		result.CommentBody = match.Body
+		result.CreatedAt = match.CreatedAt
+		result.CommentExists = true
+	}
+
+	result.Success = true
+	return result
+}
+
+// matchesComment reports whether a comment satisfies every filter that is set
+func matchesComment(comment Comment, config *Config, bodyPattern *regexp.Regexp) bool {
+	if config.Author != "" && !strings.EqualFold(comment.User.Login, config.Author) {
+		return false
+	}
+	if config.BodyContains != "" && !strings.Contains(comment.Body, config.BodyContains) {
+		return false
+	}
+	if bodyPattern != nil && !bodyPattern.MatchString(comment.Body) {
+		return false
+	}
+	if config.Marker != "" && !hasMarker(comment.Body, config.Marker) {
+		return false
+	}
+	return true
+}
+
+// hasMarker reports whether the body contains the marker as a hidden HTML comment
+func hasMarker(body, marker string) bool {
+	pattern := regexp.MustCompile(`<!--\s*` + regexp.QuoteMeta(marker) + `\s*-->`)
+	return pattern.MatchString(body)
+}
+
+// listComments returns every comment on the issue, oldest first
+func listComments(repository, issueNumber, token string) ([]Comment, error) {
+	var comments []Comment
+	for page := 1; ; page++ {
+		pageComments, err := listCommentsPage(repository, issueNumber, page, token)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, pageComments...)
+		if len(pageComments) < commentsPerPage {
+			return comments, nil
+		}
+	}
+}
+
+func listCommentsPage(repository, issueNumber string, page int, token string) ([]Comment, error) {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments?per_page=%d&page=%d",
+		apiBase, repository, issueNumber, commentsPerPage, page)
+
+	// Create request
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Check status
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var comments []Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// setMultilineOutput sets an output whose value may span lines, using the
+// GITHUB_OUTPUT heredoc syntax that actionskit.SetOutput does not support
+func setMultilineOutput(name, value string) error {
+	outputFile := os.Getenv("GITHUB_OUTPUT")
+	if outputFile == "" || !strings.Contains(value, "\n") {
+		return actionskit.SetOutput(name, value)
+	}
+
+	delimiterBytes := make([]byte, 8)
+	if _, err := rand.Read(delimiterBytes); err != nil {
+		return fmt.Errorf("error generating output delimiter: %v", err)
+	}
+	delimiter := "ghadelimiter_" + hex.EncodeToString(delimiterBytes)
+
+	f, err := os.OpenFile(outputFile, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %v", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s<<%s\n%s\n%s\n", name, delimiter, value, delimiter)
+	if err != nil {
+		return fmt.Errorf("error writing to output file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGetConfigFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupEnv    func()
+		cleanupEnv  func()
+		expectError bool
+		errorMsg    string
+		expected    *Config
+	}{
+		{
+			name: "valid configuration with defaults",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:  "test/repo",
+				IssueNumber: "123",
+				Selection:   "first",
+				Token:       "test-token",
+			},
+		},
+		{
+			name: "all filters",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_AUTHOR", "github-actions[bot]")
+				os.Setenv("INPUT_BODY_CONTAINS", "Build")
+				os.Setenv("INPUT_BODY_REGEX", `run \d+`)
+				os.Setenv("INPUT_MARKER", "build-status")
+				os.Setenv("INPUT_SELECTION", "LAST")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_AUTHOR")
+				os.Unsetenv("INPUT_BODY_CONTAINS")
+				os.Unsetenv("INPUT_BODY_REGEX")
+				os.Unsetenv("INPUT_MARKER")
+				os.Unsetenv("INPUT_SELECTION")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:   "test/repo",
+				IssueNumber:  "123",
+				Author:       "github-actions[bot]",
+				BodyContains: "Build",
+				BodyRegex:    `run \d+`,
+				Marker:       "build-status",
+				Selection:    "last",
+				Token:        "test-token",
+			},
+		},
+		{
+			name: "repository input overrides GITHUB_REPOSITORY",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_REPOSITORY", "other/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:  "other/repo",
+				IssueNumber: "123",
+				Selection:   "first",
+				Token:       "test-token",
+			},
+		},
+		{
+			name: "invalid repository format",
+			setupEnv: func() {
+				os.Setenv("INPUT_REPOSITORY", "other/repo/extra")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    `repository must be in owner/name format, got "other/repo/extra"`,
+		},
+		{
+			name: "invalid selection",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_SELECTION", "middle")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_SELECTION")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    `selection must be first or last, got "middle"`,
+		},
+		{
+			name: "invalid regex",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_BODY_REGEX", "(unclosed")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_BODY_REGEX")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    "body-regex is not a valid regular expression: error parsing regexp: missing closing ): `(unclosed`",
+		},
+		{
+			name: "missing issue number",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    "issue-number input is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupEnv()
+			defer tt.cleanupEnv()
+
+			config, err := getConfigFromEnvironment()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				} else if err.Error() != tt.errorMsg {
+					t.Errorf("Expected error message %q, got %q", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if *config != *tt.expected {
+				t.Errorf("Config = %+v, want %+v", *config, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	comments := `[
+		{"id": 1, "body": "First!", "user": {"login": "alice"}, "created_at": "2025-01-01T00:00:00Z"},
+		{"id": 2, "body": "<!-- build-status -->\nBuild run 41 passed", "user": {"login": "github-actions[bot]"}, "created_at": "2025-01-02T00:00:00Z"},
+		{"id": 3, "body": "Looks good", "user": {"login": "bob"}, "created_at": "2025-01-03T00:00:00Z"},
+		{"id": 4, "body": "<!--build-status-->\nBuild run 42 failed", "user": {"login": "github-actions[bot]"}, "created_at": "2025-01-04T00:00:00Z"}
+	]`
+
+	tests := []struct {
+		name          string
+		config        *Config
+		expectedID    int
+		expectedFound bool
+		expectError   bool
+	}{
+		{
+			name:          "no filters returns first comment",
+			config:        &Config{Selection: "first"},
+			expectedID:    1,
+			expectedFound: true,
+		},
+		{
+			name:          "no filters with last selection",
+			config:        &Config{Selection: "last"},
+			expectedID:    4,
+			expectedFound: true,
+		},
+		{
+			name:          "author is case-insensitive",
+			config:        &Config{Author: "BOB", Selection: "first"},
+			expectedID:    3,
+			expectedFound: true,
+		},
+		{
+			name:          "marker with last selection",
+			config:        &Config{Marker: "build-status", Selection: "last"},
+			expectedID:    4,
+			expectedFound: true,
+		},
+		{
+			name:          "body contains",
+			config:        &Config{BodyContains: "passed", Selection: "first"},
+			expectedID:    2,
+			expectedFound: true,
+		},
+		{
+			name:          "regex combined with author",
+			config:        &Config{Author: "github-actions[bot]", BodyRegex: `run 4[2-9]`, Selection: "first"},
+			expectedID:    4,
+			expectedFound: true,
+		},
+		{
+			name:          "no match",
+			config:        &Config{Author: "carol", Selection: "first"},
+			expectedID:    0,
+			expectedFound: false,
+		},
+		{
+			name:        "invalid regex",
+			config:      &Config{BodyRegex: "(", Selection: "first"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, comments)
+			}))
+			defer server.Close()
+
+			os.Setenv("GITHUB_API_URL", server.URL)
+			defer os.Unsetenv("GITHUB_API_URL")
+
+			tt.config.Repository = "test/repo"
+			tt.config.IssueNumber = "123"
+			tt.config.Token = "test-token"
+
+			result := run(tt.config)
+
+			if tt.expectError {
+				if result.Error == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if result.Error != nil {
+				t.Fatalf("Unexpected error: %v", result.Error)
+			}
+			if result.CommentID != tt.expectedID {
+				t.Errorf("CommentID = %d, want %d", result.CommentID, tt.expectedID)
+			}
+			if result.CommentExists != tt.expectedFound {
+				t.Errorf("CommentExists = %v, want %v", result.CommentExists, tt.expectedFound)
+			}
+		})
+	}
+}
+
+func TestListCommentsPaging(t *testing.T) {
+	totalComments := commentsPerPage + 5
+	var requestedPages []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/test/repo/issues/123/comments" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("per_page") != strconv.Itoa(commentsPerPage) {
+			t.Errorf("Expected per_page=%d, got %s", commentsPerPage, r.URL.Query().Get("per_page"))
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+
+		var comments []Comment
+		for id := (page-1)*commentsPerPage + 1; id <= totalComments && id <= page*commentsPerPage; id++ {
+			comments = append(comments, Comment{ID: id})
+		}
+		if comments == nil {
+			comments = []Comment{}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(comments)
+	}))
+	defer server.Close()
+
+	os.Setenv("GITHUB_API_URL", server.URL)
+	defer os.Unsetenv("GITHUB_API_URL")
+
+	comments, err := listComments("test/repo", "123", "test-token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(comments) != totalComments {
+		t.Errorf("Expected %d comments, got %d", totalComments, len(comments))
+	}
+	if strings.Join(requestedPages, ",") != "1,2" {
+		t.Errorf("Expected pages 1,2 to be requested, got %v", requestedPages)
+	}
+	if comments[len(comments)-1].ID != totalComments {
+		t.Errorf("Expected last comment ID %d, got %d", totalComments, comments[len(comments)-1].ID)
+	}
+}
+
+func TestListCommentsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}))
+	defer server.Close()
+
+	os.Setenv("GITHUB_API_URL", server.URL)
+	defer os.Unsetenv("GITHUB_API_URL")
+
+	_, err := listComments("test/repo", "999", "test-token")
+	if err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected status 404 error, got %v", err)
+	}
+}
+
+func TestSetMultilineOutput(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output")
+	if err := os.WriteFile(outputFile, nil, 0644); err != nil {
+		t.Fatalf("Failed to create output file: %v", err)
+	}
+
+	os.Setenv("GITHUB_OUTPUT", outputFile)
+	defer os.Unsetenv("GITHUB_OUTPUT")
+
+	if err := setMultilineOutput("comment-body", "line one\nline two"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(outputFile)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines in output file, got %d: %q", len(lines), content)
+	}
+
+	delimiter := strings.TrimPrefix(lines[0], "comment-body<<")
+	if delimiter == lines[0] || delimiter == "" {
+		t.Errorf("Expected heredoc header, got %q", lines[0])
+	}
+	if lines[1] != "line one" || lines[2] != "line two" {
+		t.Errorf("Unexpected body lines: %q", lines[1:3])
+	}
+	if lines[3] != delimiter {
+		t.Errorf("Expected closing delimiter %q, got %q", delimiter, lines[3])
+	}
+}
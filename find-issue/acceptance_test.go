@@ -285,6 +285,94 @@ func TestAcceptanceFindIssueCaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestAcceptanceFindIssueOtherRepository(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that only serves issues for the target repository
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/repos/other/service/issues" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `[{"number": 31, "title": "Dependency update failed", "state": "open"}]`)
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_REPOSITORY":   "other/service",
+		"INPUT_ISSUE_TITLE":  "Dependency update failed",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stdout, "Found existing issue #31") {
+		t.Errorf("Expected stdout to contain 'Found existing issue #31', got: %s", stdout)
+	}
+}
+
+func TestAcceptanceFindIssueOtherRepositoryNotFound(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that reports the repository as missing, as GitHub does without access
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_REPOSITORY":   "other/service",
+		"INPUT_ISSUE_TITLE":  "Dependency update failed",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	expectedStderr := []string{"error finding issues in other/service", "API request failed with status 404"}
+	for _, expected := range expectedStderr {
+		if !strings.Contains(stderr, expected) {
+			t.Errorf("Expected stderr to contain %q, got: %s", expected, stderr)
+		}
+	}
+}
+
 // setupEnv sets environment variables and returns the old values for restoration
 func setupEnv(envVars map[string]string) map[string]string {
 	oldEnv := make(map[string]string)
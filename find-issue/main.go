@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/half-ogre/go-kit/actionskit"
@@ -32,6 +33,9 @@ type Result struct {
 	Error       error
 }
 
+// repositoryPattern matches an owner/name repository reference
+var repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
 func main() {
 	config, err := getConfigFromEnvironment()
 	if err != nil {
@@ -68,10 +72,16 @@ func main() {
 
 // getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
 func getConfigFromEnvironment() (*Config, error) {
-	repository := os.Getenv("GITHUB_REPOSITORY")
+	repository := actionskit.GetInput("repository")
+	if repository == "" {
+		repository = os.Getenv("GITHUB_REPOSITORY")
+	}
 	if repository == "" {
 		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
 	}
+	if !repositoryPattern.MatchString(repository) {
+		return nil, fmt.Errorf("repository must be in owner/name format, got %q", repository)
+	}
 
 	title := actionskit.GetInput("issue-title")
 	if title == "" {
@@ -97,7 +107,7 @@ func run(config *Config) *Result {
 	// Search for open issues with the title
 	issues, err := findIssues(config.Repository, config.Title, config.Token)
 	if err != nil {
-		result.Error = fmt.Errorf("error finding issues: %v", err)
+		result.Error = fmt.Errorf("error finding issues in %s: %v", config.Repository, err)
 		return result
 	}
 
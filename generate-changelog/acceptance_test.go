@@ -0,0 +1,204 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Acceptance tests that build and run the actual binary in a git repository
+func TestAcceptanceGenerateChangelog(t *testing.T) {
+	// Skip this test when running in GitHub Actions since outputs go to file instead of stdout
+	if os.Getenv("GITHUB_OUTPUT") != "" {
+		t.Skip("Skipping output format test in GitHub Actions environment where outputs go to file")
+	}
+
+	// Create temporary directory for building
+	tempBuildDir, err := os.MkdirTemp("", "build-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp build dir: %v", err)
+	}
+	defer os.RemoveAll(tempBuildDir)
+
+	// Build the binary in temp directory
+	binaryPath := filepath.Join(tempBuildDir, "generate-changelog")
+
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, "main.go")
+	if err := buildCmd.Run(); err != nil {
+		t.Fatalf("Failed to build binary: %v", err)
+	}
+
+	tests := []struct {
+		name              string
+		env               map[string]string
+		existingChangelog string
+		expectedOutputs   []string
+		expectedChangelog []string
+		expectedError     bool
+	}{
+		{
+			name: "between the previous tag and the to-tag",
+			env: map[string]string{
+				"INPUT_TO_TAG":      "v1.3.0",
+				"GITHUB_REPOSITORY": "owner/repo",
+			},
+			expectedOutputs: []string{
+				"Generated changelog for 1.3.0 from v1.2.0 to v1.3.0",
+				"Updated CHANGELOG.md",
+				"::set-output name=from-tag::v1.2.0",
+			},
+			expectedChangelog: []string{
+				"# Changelog\n",
+				"## [1.3.0](https://github.com/owner/repo/compare/v1.2.0...v1.3.0) - ",
+				"### Features\n\n- **api:** add paging ([#12](https://github.com/owner/repo/pull/12))",
+				"### Bug Fixes\n\n- handle empty tags (",
+			},
+		},
+		{
+			name: "explicit from-tag into an existing changelog",
+			env: map[string]string{
+				"INPUT_FROM_TAG": "v1.1.0",
+				"INPUT_TO_TAG":   "v1.2.0",
+			},
+			existingChangelog: "# Changelog\n\n## [Unreleased]\n\n## [1.1.0] - 2026-01-01\n\n- first\n",
+			expectedChangelog: []string{
+				"## [Unreleased]\n\n## [1.2.0] - ",
+				"### Features\n\n- add exports (",
+				"\n\n## [1.1.0] - 2026-01-01\n\n- first\n",
+			},
+		},
+		{
+			name: "output only",
+			env: map[string]string{
+				"INPUT_TO_TAG":      "v1.3.0",
+				"INPUT_UPDATE_FILE": "false",
+			},
+			expectedOutputs: []string{
+				"::set-output name=changelog::## [1.3.0] - ",
+				"::set-output name=path::\n",
+			},
+		},
+		{
+			name: "version for a branch",
+			env: map[string]string{
+				"INPUT_FROM_TAG": "v1.3.0",
+				"INPUT_TO_TAG":   "HEAD",
+				"INPUT_VERSION":  "v1.4.0",
+			},
+			expectedChangelog: []string{
+				"## [1.4.0] - ",
+				"### Performance Improvements\n\n- cache tags (",
+			},
+		},
+		{
+			name: "branch without a version",
+			env: map[string]string{
+				"INPUT_TO_TAG": "HEAD",
+			},
+			expectedError: true,
+		},
+		{
+			name: "version already in the changelog",
+			env: map[string]string{
+				"INPUT_TO_TAG": "v1.3.0",
+			},
+			existingChangelog: "# Changelog\n\n## [1.3.0] - 2026-01-01\n",
+			expectedError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a git repository with a history of tagged releases
+			tempDir, err := os.MkdirTemp("", "git-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			git := func(args ...string) {
+				cmd := exec.Command("git", args...)
+				cmd.Dir = tempDir
+				if output, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+				}
+			}
+
+			git("init")
+			git("config", "user.email", "test@example.com")
+			git("config", "user.name", "Test User")
+			git("commit", "--allow-empty", "-m", "feat: initial feature")
+			git("tag", "v1.1.0")
+			git("commit", "--allow-empty", "-m", "feat: add exports")
+			git("tag", "v1.2.0")
+			git("commit", "--allow-empty", "-m", "feat(api): add paging (#12)")
+			git("commit", "--allow-empty", "-m", "fix: handle empty tags")
+			git("commit", "--allow-empty", "-m", "chore: bump deps")
+			git("tag", "v1.3.0")
+			git("commit", "--allow-empty", "-m", "perf: cache tags")
+
+			changelogPath := filepath.Join(tempDir, "CHANGELOG.md")
+			if tt.existingChangelog != "" {
+				if err := os.WriteFile(changelogPath, []byte(tt.existingChangelog), 0644); err != nil {
+					t.Fatalf("Failed to write CHANGELOG.md: %v", err)
+				}
+			}
+
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			// Run the binary in the git repository
+			cmd := exec.Command(binaryPath)
+			cmd.Dir = tempDir
+			output, err := cmd.CombinedOutput()
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected error but command succeeded\nOutput: %s", output)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v\nOutput: %s", err, output)
+				return
+			}
+
+			outputStr := string(output)
+			for _, expectedOutput := range tt.expectedOutputs {
+				if !contains(outputStr, expectedOutput) {
+					t.Errorf("Expected output to contain %q, got: %s", expectedOutput, outputStr)
+				}
+			}
+
+			if len(tt.expectedChangelog) == 0 {
+				return
+			}
+
+			changelog, err := os.ReadFile(changelogPath)
+			if err != nil {
+				t.Fatalf("Failed to read CHANGELOG.md: %v", err)
+			}
+			for _, expected := range tt.expectedChangelog {
+				if !contains(string(changelog), expected) {
+					t.Errorf("Expected CHANGELOG.md to contain %q, got: %s", expected, changelog)
+				}
+			}
+			if contains(string(changelog), "bump deps") {
+				t.Errorf("Expected CHANGELOG.md to leave out chore commits, got: %s", changelog)
+			}
+		})
+	}
+}
+
+func contains(s, substr string) bool {
+	return strings.Contains(s, substr)
+}
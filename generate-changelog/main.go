@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/half-ogre/go-kit/actionskit"
+	"github.com/half-ogre/go-kit/versionkit"
+	"github.com/half-ogre-games/hog-actions/internal/semveractions"
+)
+
+// Config holds the configuration for the generate-changelog action
+type Config struct {
+	FromTag    string // Empty to use the previous semver tag
+	ToTag      string
+	Version    string // Empty to use the version of ToTag
+	Prefix     string
+	Path       string
+	UpdateFile bool
+}
+
+// Result holds the result of the generate-changelog action
+type Result struct {
+	FromTag   string
+	ToTag     string
+	Version   string
+	Changelog string // The generated section
+	Path      string // The updated file, empty when it was not updated
+	Success   bool
+	Error     error
+}
+
+func main() {
+	config, err := getConfigFromEnvironment()
+	if err != nil {
+		actionskit.Error(err.Error())
+		os.Exit(1)
+	}
+
+	result := run(config)
+	if result.Error != nil {
+		actionskit.Error(result.Error.Error())
+		os.Exit(1)
+	}
+
+	// Output results
+	if result.FromTag != "" {
+		actionskit.Info(fmt.Sprintf("Generated changelog for %s from %s to %s", result.Version, result.FromTag, result.ToTag))
+	} else {
+		actionskit.Info(fmt.Sprintf("Generated changelog for %s from the start of history to %s", result.Version, result.ToTag))
+	}
+	if result.Path != "" {
+		actionskit.Info(fmt.Sprintf("Updated %s", result.Path))
+	}
+
+	// Set outputs for GitHub Actions
+	err = semveractions.SetMultilineOutput("changelog", result.Changelog)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set changelog output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("version", result.Version)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set version output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("from-tag", result.FromTag)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set from-tag output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("path", result.Path)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set path output: %v", err))
+		os.Exit(1)
+	}
+}
+
+// getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
+func getConfigFromEnvironment() (*Config, error) {
+	toTag, err := actionskit.GetInputRequired("to-tag")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := actionskit.GetInput("prefix")
+	if prefix == "" {
+		prefix = "v"
+	}
+
+	path := actionskit.GetInput("path")
+	if path == "" {
+		path = "CHANGELOG.md"
+	}
+
+	updateFile := actionskit.GetInput("update-file") != "false"
+
+	return &Config{
+		FromTag:    actionskit.GetInput("from-tag"),
+		ToTag:      toTag,
+		Version:    strings.TrimPrefix(actionskit.GetInput("version"), prefix),
+		Prefix:     prefix,
+		Path:       path,
+		UpdateFile: updateFile,
+	}, nil
+}
+
+// run executes the generate-changelog action with the given configuration
+func run(config *Config) *Result {
+	result := &Result{Success: false, ToTag: config.ToTag}
+
+	// Resolve the version heading, from the to-tag unless one is given
+	var toSemver *versionkit.SemanticVersion
+	if semver, versionWithoutPrefix, err := semveractions.ParseVersionWithPrefix(config.ToTag, config.Prefix); err == nil {
+		toSemver = semver
+		result.Version = versionWithoutPrefix
+	}
+	if config.Version != "" {
+		result.Version = config.Version
+	}
+	if result.Version == "" {
+		result.Error = fmt.Errorf("version is required when to-tag %s is not a semver tag", config.ToTag)
+		return result
+	}
+
+	// Default the from-tag to the semver tag before the to-tag
+	result.FromTag = config.FromTag
+	if result.FromTag == "" && toSemver != nil {
+		tags, err := semveractions.GetAllTags()
+		if err != nil {
+			result.Error = fmt.Errorf("error getting tags: %v", err)
+			return result
+		}
+
+		result.FromTag, _, err = findPreviousSemverTag(tags, config.Prefix, toSemver)
+		if err != nil {
+			result.Error = fmt.Errorf("error finding previous tag: %v", err)
+			return result
+		}
+	}
+
+	commits, err := semveractions.GetCommitsSince(result.FromTag, config.ToTag)
+	if err != nil {
+		result.Error = fmt.Errorf("error getting commits: %v", err)
+		return result
+	}
+
+	date, err := semveractions.GetCommitDate(config.ToTag)
+	if err != nil {
+		result.Error = fmt.Errorf("error getting release date: %v", err)
+		return result
+	}
+
+	result.Changelog = semveractions.GenerateChangelogSection(semveractions.ChangelogRelease{
+		Version:       result.Version,
+		Tag:           config.ToTag,
+		PreviousTag:   result.FromTag,
+		Date:          date,
+		RepositoryURL: semveractions.GetRepositoryURL(),
+	}, commits)
+
+	if config.UpdateFile {
+		if err := updateChangelogFile(config.Path, result.Changelog, result.Version); err != nil {
+			result.Error = err
+			return result
+		}
+		result.Path = config.Path
+	}
+
+	result.Success = true
+	return result
+}
+
+// findPreviousSemverTag finds the latest semver tag that precedes version
+func findPreviousSemverTag(tags []string, prefix string, version *versionkit.SemanticVersion) (string, bool, error) {
+	var earlierTags []string
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+
+		semver, _, err := semveractions.ParseVersionWithPrefix(tag, prefix)
+		if err != nil {
+			continue // Skip invalid versions
+		}
+
+		if semver.Compare(*version) < 0 {
+			earlierTags = append(earlierTags, tag)
+		}
+	}
+
+	return semveractions.FindLatestSemverTag(earlierTags, prefix)
+}
+
+// updateChangelogFile inserts a section into the changelog file, creating it if needed
+func updateChangelogFile(path, section, version string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	changelog, err := semveractions.InsertChangelogSection(string(existing), section, version)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(changelog), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/half-ogre/go-kit/versionkit"
+)
+
+func TestGetConfigFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		expected      *Config
+		expectedError string
+	}{
+		{
+			name: "default configuration",
+			env: map[string]string{
+				"INPUT_TO_TAG": "v1.3.0",
+			},
+			expected: &Config{
+				ToTag:      "v1.3.0",
+				Prefix:     "v",
+				Path:       "CHANGELOG.md",
+				UpdateFile: true,
+			},
+		},
+		{
+			name: "custom configuration",
+			env: map[string]string{
+				"INPUT_FROM_TAG":    "release-1.2.0",
+				"INPUT_TO_TAG":      "main",
+				"INPUT_VERSION":     "release-1.3.0",
+				"INPUT_PREFIX":      "release-",
+				"INPUT_PATH":        "docs/CHANGES.md",
+				"INPUT_UPDATE_FILE": "false",
+			},
+			expected: &Config{
+				FromTag:    "release-1.2.0",
+				ToTag:      "main",
+				Version:    "1.3.0",
+				Prefix:     "release-",
+				Path:       "docs/CHANGES.md",
+				UpdateFile: false,
+			},
+		},
+		{
+			name:          "missing to-tag",
+			env:           map[string]string{},
+			expectedError: "to-tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			config, err := getConfigFromEnvironment()
+
+			if tt.expectedError != "" {
+				if err == nil {
+					t.Fatalf("Expected error containing %q, got nil", tt.expectedError)
+				}
+				if !contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %q", tt.expectedError, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("Expected config %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestFindPreviousSemverTag(t *testing.T) {
+	tags := []string{"v1.0.0", "v1.2.0", "v1.3.0-rc.1", "v1.3.0", "v2.0.0", "v1", "latest", "release-1.2.5"}
+
+	tests := []struct {
+		name     string
+		version  versionkit.SemanticVersion
+		expected string
+		found    bool
+	}{
+		{
+			name:     "previous release",
+			version:  versionkit.SemanticVersion{MajorVersion: 2},
+			expected: "v1.3.0",
+			found:    true,
+		},
+		{
+			name:     "prerelease precedes its release",
+			version:  versionkit.SemanticVersion{MajorVersion: 1, MinorVersion: 3},
+			expected: "v1.3.0-rc.1",
+			found:    true,
+		},
+		{
+			name:     "first release",
+			version:  versionkit.SemanticVersion{MajorVersion: 1},
+			expected: "",
+			found:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, found, err := findPreviousSemverTag(tags, "v", &tt.version)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tag != tt.expected || found != tt.found {
+				t.Errorf("findPreviousSemverTag(%s) = %q, %v, want %q, %v", tt.version.String(), tag, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
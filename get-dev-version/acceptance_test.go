@@ -0,0 +1,164 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Acceptance tests that build and run the actual binary in a git repository
+func TestAcceptanceGetDevVersion(t *testing.T) {
+	// Skip this test when running in GitHub Actions since outputs go to file instead of stdout
+	if os.Getenv("GITHUB_OUTPUT") != "" {
+		t.Skip("Skipping output format test in GitHub Actions environment where outputs go to file")
+	}
+
+	// Create temporary directory for building
+	tempBuildDir, err := os.MkdirTemp("", "build-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp build dir: %v", err)
+	}
+	defer os.RemoveAll(tempBuildDir)
+
+	// Build the binary in temp directory
+	binaryPath := filepath.Join(tempBuildDir, "get-dev-version")
+
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, "main.go")
+	if err := buildCmd.Run(); err != nil {
+		t.Fatalf("Failed to build binary: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		env             map[string]string
+		tag             string
+		commits         int
+		expectedOutputs []string
+		expectedError   bool
+	}{
+		{
+			name:    "commits since the latest tag",
+			tag:     "v1.4.2",
+			commits: 7,
+			expectedOutputs: []string{
+				"Found latest tag: v1.4.2 (7 commits since)",
+				"::set-output name=version::v1.4.3-dev.7+g{sha}",
+				"::set-output name=version-core::1.4.3-dev.7+g{sha}",
+				"::set-output name=tag::v1.4.2",
+				"::set-output name=commits-since-tag::7",
+				"::set-output name=distance-from-tag::v1.4.2-7-g{sha}",
+			},
+		},
+		{
+			name:    "commit is the latest tag",
+			tag:     "v1.4.2",
+			commits: 0,
+			expectedOutputs: []string{
+				"::set-output name=version::v1.4.2\n",
+				"::set-output name=commits-since-tag::0",
+				"::set-output name=distance-from-tag::v1.4.2-0-g{sha}",
+			},
+		},
+		{
+			name: "minor increment with custom preid",
+			env: map[string]string{
+				"INPUT_INCREMENT": "minor",
+				"INPUT_PREID":     "snapshot",
+			},
+			tag:     "v1.4.2",
+			commits: 2,
+			expectedOutputs: []string{
+				"::set-output name=version::v1.5.0-snapshot.2+g{sha}",
+			},
+		},
+		{
+			name:    "no tags counts the whole history",
+			commits: 2,
+			expectedOutputs: []string{
+				"No tags found, using default: v0.0.0 (3 commits since)",
+				"::set-output name=version::v0.0.1-dev.3+g{sha}",
+				"::set-output name=distance-from-tag::v0.0.0-3-g{sha}",
+			},
+		},
+		{
+			name: "invalid increment",
+			env: map[string]string{
+				"INPUT_INCREMENT": "premajor",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a git repository with the tag before the new commits
+			tempDir, err := os.MkdirTemp("", "git-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			git := func(args ...string) string {
+				cmd := exec.Command("git", args...)
+				cmd.Dir = tempDir
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+				}
+				return strings.TrimSpace(string(output))
+			}
+
+			git("init")
+			git("config", "user.email", "test@example.com")
+			git("config", "user.name", "Test User")
+			git("commit", "--allow-empty", "-m", "chore: initial commit")
+			if tt.tag != "" {
+				git("tag", tt.tag)
+			}
+			for i := 0; i < tt.commits; i++ {
+				git("commit", "--allow-empty", "-m", "fix: change")
+			}
+			shortSHA := git("rev-parse", "HEAD")[:7]
+
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			// Run the binary in the git repository
+			cmd := exec.Command(binaryPath)
+			cmd.Dir = tempDir
+			output, err := cmd.CombinedOutput()
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected error but command succeeded\nOutput: %s", output)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v\nOutput: %s", err, output)
+				return
+			}
+
+			outputStr := string(output)
+			for _, expectedOutput := range tt.expectedOutputs {
+				expectedOutput = strings.ReplaceAll(expectedOutput, "{sha}", shortSHA)
+				if !contains(outputStr, expectedOutput) {
+					t.Errorf("Expected output to contain %q, got: %s", expectedOutput, outputStr)
+				}
+			}
+		})
+	}
+}
+
+func contains(s, substr string) bool {
+	return strings.Contains(s, substr)
+}
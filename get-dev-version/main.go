@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/half-ogre/go-kit/actionskit"
+	"github.com/half-ogre-games/hog-actions/internal/semveractions"
+)
+
+// Config holds the configuration for the get-dev-version action
+type Config struct {
+	Prefix         string
+	DefaultVersion string
+	Increment      string
+	Preid          string
+	Commit         string
+}
+
+// Result holds the result of the get-dev-version action
+type Result struct {
+	Version         string
+	VersionCore     string
+	Tag             string
+	Found           bool
+	CommitsSinceTag int
+	DistanceFromTag string
+	Success         bool
+	Error           error
+}
+
+func main() {
+	config, err := getConfigFromEnvironment()
+	if err != nil {
+		actionskit.Error(err.Error())
+		os.Exit(1)
+	}
+
+	result := run(config)
+	if result.Error != nil {
+		actionskit.Error(result.Error.Error())
+		os.Exit(1)
+	}
+
+	// Output results
+	if result.Found {
+		actionskit.Info(fmt.Sprintf("Found latest tag: %s (%d commits since)", result.Tag, result.CommitsSinceTag))
+	} else {
+		actionskit.Info(fmt.Sprintf("No tags found, using default: %s (%d commits since)", result.Tag, result.CommitsSinceTag))
+	}
+	actionskit.Info(fmt.Sprintf("Dev version: %s", result.Version))
+
+	// Set outputs for GitHub Actions
+	err = actionskit.SetOutput("version", result.Version)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set version output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("version-core", result.VersionCore)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set version-core output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("tag", result.Tag)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set tag output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("commits-since-tag", fmt.Sprintf("%d", result.CommitsSinceTag))
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set commits-since-tag output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("distance-from-tag", result.DistanceFromTag)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set distance-from-tag output: %v", err))
+		os.Exit(1)
+	}
+}
+
+// getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
+func getConfigFromEnvironment() (*Config, error) {
+	prefix := actionskit.GetInput("prefix")
+	if prefix == "" {
+		prefix = "v"
+	}
+
+	defaultVersion := actionskit.GetInput("default-version")
+	if defaultVersion == "" {
+		defaultVersion = "v0.0.0"
+	}
+
+	increment := strings.ToLower(strings.TrimSpace(actionskit.GetInput("increment")))
+	if increment == "" {
+		increment = "patch"
+	}
+	if increment != "major" && increment != "minor" && increment != "patch" {
+		return nil, fmt.Errorf("increment must be one of major, minor, patch, got %q", increment)
+	}
+
+	preid := actionskit.GetInput("preid")
+	if preid == "" {
+		preid = "dev"
+	}
+	if err := semveractions.ValidatePreid(preid); err != nil {
+		return nil, err
+	}
+
+	commit := actionskit.GetInput("commit")
+	if commit == "" {
+		commit = "HEAD"
+	}
+
+	return &Config{
+		Prefix:         prefix,
+		DefaultVersion: defaultVersion,
+		Increment:      increment,
+		Preid:          preid,
+		Commit:         commit,
+	}, nil
+}
+
+// run executes the get-dev-version action with the given configuration
+func run(config *Config) *Result {
+	result := &Result{Success: false}
+
+	// Find the latest version tag reachable from the commit, falling back to the
+	// default version
+	tags, err := semveractions.GetMergedTags(config.Commit)
+	if err != nil {
+		result.Error = fmt.Errorf("error getting tags: %v", err)
+		return result
+	}
+
+	latestTag, found, err := semveractions.FindLatestSemverTag(tags, config.Prefix)
+	if err != nil {
+		result.Error = fmt.Errorf("error finding latest tag: %v", err)
+		return result
+	}
+
+	since := ""
+	result.Tag = config.DefaultVersion
+	if found {
+		since = latestTag
+		result.Tag = latestTag
+	}
+	result.Found = found
+
+	currentSemver, _, err := semveractions.ParseVersionWithPrefix(result.Tag, config.Prefix)
+	if err != nil {
+		result.Error = fmt.Errorf("error parsing current version: %v", err)
+		return result
+	}
+
+	// Count the commits since the tag, or the whole history without one
+	count, err := semveractions.CountCommitsSince(since, config.Commit)
+	if err != nil {
+		result.Error = fmt.Errorf("error counting commits: %v", err)
+		return result
+	}
+	result.CommitsSinceTag = count
+
+	metadata, err := semveractions.GetBuildMetadata(config.Commit)
+	if err != nil {
+		result.Error = fmt.Errorf("error getting commit: %v", err)
+		return result
+	}
+	result.DistanceFromTag = fmt.Sprintf("%s-%d-g%s", result.Tag, count, metadata.ShortSHA)
+
+	// A commit that is exactly the latest tag is that version; anything after it is
+	// the next version's dev prerelease, e.g. 1.4.3-dev.7+g1a2b3c4
+	devSemver, err := semveractions.IncrementVersion(currentSemver, "none", "")
+	if err != nil {
+		result.Error = fmt.Errorf("error calculating version: %v", err)
+		return result
+	}
+	if !found || count > 0 {
+		// After a prerelease tag the dev version extends its prerelease, e.g.
+		// 1.4.0-rc.1.dev.3, which sorts after the tag and before the next prerelease.
+		// Incrementing would drop to 1.4.0-dev.3, below the tag it was built from.
+		if currentSemver.PreReleaseVersion != "" {
+			devSemver.PreReleaseVersion = fmt.Sprintf("%s.%s.%d", currentSemver.PreReleaseVersion, config.Preid, count)
+		} else {
+			devSemver, err = semveractions.IncrementVersion(currentSemver, config.Increment, "")
+			if err != nil {
+				result.Error = fmt.Errorf("error incrementing version: %v", err)
+				return result
+			}
+			devSemver.PreReleaseVersion = fmt.Sprintf("%s.%d", config.Preid, count)
+		}
+		devSemver.BuildMetadata = "g" + metadata.ShortSHA
+	}
+
+	result.Version = semveractions.FormatVersionWithPrefix(devSemver, config.Prefix)
+	result.VersionCore = semveractions.FormatVersionWithPrefix(devSemver, "")
+	result.Success = true
+	return result
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetConfigFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		expected      *Config
+		expectedError string
+	}{
+		{
+			name: "default configuration",
+			env:  map[string]string{},
+			expected: &Config{
+				Prefix:         "v",
+				DefaultVersion: "v0.0.0",
+				Increment:      "patch",
+				Preid:          "dev",
+				Commit:         "HEAD",
+			},
+		},
+		{
+			name: "custom configuration",
+			env: map[string]string{
+				"INPUT_PREFIX":          "release-",
+				"INPUT_DEFAULT_VERSION": "release-1.0.0",
+				"INPUT_INCREMENT":       "Minor",
+				"INPUT_PREID":           "snapshot",
+				"INPUT_COMMIT":          "main",
+			},
+			expected: &Config{
+				Prefix:         "release-",
+				DefaultVersion: "release-1.0.0",
+				Increment:      "minor",
+				Preid:          "snapshot",
+				Commit:         "main",
+			},
+		},
+		{
+			name: "prerelease increments are not supported",
+			env: map[string]string{
+				"INPUT_INCREMENT": "prerelease",
+			},
+			expectedError: `increment must be one of major, minor, patch, got "prerelease"`,
+		},
+		{
+			name: "invalid preid",
+			env: map[string]string{
+				"INPUT_PREID": "dev_build",
+			},
+			expectedError: "preid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			config, err := getConfigFromEnvironment()
+
+			if tt.expectedError != "" {
+				if err == nil {
+					t.Fatalf("Expected error containing %q, got nil", tt.expectedError)
+				}
+				if !contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %q", tt.expectedError, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("Expected config %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name            string
+		tags            map[int]string
+		commits         int
+		commit          int
+		expectedTag     string
+		expectedFound   bool
+		expectedCount   int
+		expectedVersion string
+	}{
+		{
+			name:            "untagged commit",
+			commits:         3,
+			commit:          2,
+			expectedTag:     "v0.0.0",
+			expectedCount:   3,
+			expectedVersion: "v0.0.1-dev.3+g%s",
+		},
+		{
+			name:            "commit on a tag",
+			tags:            map[int]string{0: "v1.0.0", 2: "v1.1.0"},
+			commits:         3,
+			commit:          2,
+			expectedTag:     "v1.1.0",
+			expectedFound:   true,
+			expectedVersion: "v1.1.0",
+		},
+		{
+			name:            "commit behind the newest tag",
+			tags:            map[int]string{0: "v1.0.0", 2: "v1.1.0"},
+			commits:         3,
+			commit:          1,
+			expectedTag:     "v1.0.0",
+			expectedFound:   true,
+			expectedCount:   1,
+			expectedVersion: "v1.0.1-dev.1+g%s",
+		},
+		{
+			name:            "commit after a prerelease tag",
+			tags:            map[int]string{0: "v1.4.0-rc.1"},
+			commits:         3,
+			commit:          2,
+			expectedTag:     "v1.4.0-rc.1",
+			expectedFound:   true,
+			expectedCount:   2,
+			expectedVersion: "v1.4.0-rc.1.dev.2+g%s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+
+			git := func(args ...string) string {
+				cmd := exec.Command("git", args...)
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+				}
+				return strings.TrimSpace(string(output))
+			}
+
+			git("init")
+			git("config", "user.email", "test@example.com")
+			git("config", "user.name", "Test User")
+			var shas []string
+			for i := 0; i < tt.commits; i++ {
+				git("commit", "--allow-empty", "-m", fmt.Sprintf("fix: change %d", i))
+				shas = append(shas, git("rev-parse", "HEAD"))
+				if tag, ok := tt.tags[i]; ok {
+					git("tag", tag)
+				}
+			}
+
+			result := run(&Config{
+				Prefix:         "v",
+				DefaultVersion: "v0.0.0",
+				Increment:      "patch",
+				Preid:          "dev",
+				Commit:         shas[tt.commit],
+			})
+			if result.Error != nil {
+				t.Fatalf("Unexpected error: %v", result.Error)
+			}
+
+			expectedVersion := tt.expectedVersion
+			if strings.Contains(expectedVersion, "%s") {
+				expectedVersion = fmt.Sprintf(expectedVersion, shas[tt.commit][:7])
+			}
+			if result.Version != expectedVersion {
+				t.Errorf("Expected version %q, got %q", expectedVersion, result.Version)
+			}
+			if result.Tag != tt.expectedTag {
+				t.Errorf("Expected tag %q, got %q", tt.expectedTag, result.Tag)
+			}
+			if result.Found != tt.expectedFound {
+				t.Errorf("Expected found %v, got %v", tt.expectedFound, result.Found)
+			}
+			if result.CommitsSinceTag != tt.expectedCount {
+				t.Errorf("Expected %d commits since tag, got %d", tt.expectedCount, result.CommitsSinceTag)
+			}
+		})
+	}
+}
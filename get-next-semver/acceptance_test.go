@@ -77,7 +77,7 @@ func TestAcceptanceGetNextSemver(t *testing.T) {
 			expectedError:  false,
 		},
 		{
-			name: "version with prerelease - removes prerelease",
+			name: "version with prerelease - patch promotes prerelease",
 			setupEnv: func() {
 				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3-alpha.1")
 				os.Setenv("INPUT_PREFIX", "v")
@@ -86,9 +86,53 @@ func TestAcceptanceGetNextSemver(t *testing.T) {
 				os.Unsetenv("INPUT_CURRENT_VERSION")
 				os.Unsetenv("INPUT_PREFIX")
 			},
-			expectedOutput: "Next version: v1.2.4 (patch increment)",
+			expectedOutput: "Next version: v1.2.3 (patch increment)",
+		},
+		{
+			name: "minor prerelease - minor promotes prerelease",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.3.0-rc.2")
+				os.Setenv("INPUT_INCREMENT_MINOR", "true")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT_MINOR")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedOutput: "Next version: v1.3.0 (minor increment)",
 			expectedError:  false,
 		},
+		{
+			name: "release promotes prerelease",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.3.0-rc.2")
+				os.Setenv("INPUT_INCREMENT", "release")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedOutput: "Next version: v1.3.0 (release increment)",
+			expectedError:  false,
+		},
+		{
+			name: "release of a release version",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.3.0")
+				os.Setenv("INPUT_INCREMENT", "release")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedError: true,
+			expectStderr:  true,
+		},
 		{
 			name: "version with build metadata - removes build metadata",
 			setupEnv: func() {
@@ -117,6 +161,40 @@ func TestAcceptanceGetNextSemver(t *testing.T) {
 			expectedOutput: "Next version: v1.3.0 (minor increment)",
 			expectedError:  false,
 		},
+		{
+			name: "release candidate increments numerically",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.3.0-rc.9")
+				os.Setenv("INPUT_INCREMENT", "prerelease")
+				os.Setenv("INPUT_PREID", "rc")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREID")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedOutput: "Next version: v1.3.0-rc.10 (prerelease increment)",
+			expectedError:  false,
+		},
+		{
+			name: "invalid preid",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "prepatch")
+				os.Setenv("INPUT_PREID", "rc_1")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREID")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedError: true,
+			expectStderr:  true,
+		},
 		{
 			name: "no prefix",
 			setupEnv: func() {
@@ -201,6 +279,64 @@ func TestAcceptanceGetNextSemver(t *testing.T) {
 			expectedError: true,
 			expectStderr:  true,
 		},
+		{
+			name: "increment input",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "major")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedOutput: "Next version: v2.0.0 (major increment)",
+			expectedError:  false,
+		},
+		{
+			name: "deprecated increment-minor warns",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT_MINOR", "true")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT_MINOR")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedOutput: "::warning::increment-major and increment-minor are deprecated; use increment: minor instead",
+			expectedError:  false,
+		},
+		{
+			name: "none keeps the current version",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "none")
+				os.Setenv("INPUT_PREFIX", "v")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREFIX")
+			},
+			expectedOutput: "Next version: v1.2.3 (none increment)",
+			expectedError:  false,
+		},
+		{
+			name: "unknown increment",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "huge")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+			},
+			expectedError: true,
+			expectStderr:  true,
+		},
 		{
 			name: "default prefix when not specified",
 			setupEnv: func() {
@@ -276,7 +412,10 @@ func checkOutputsInAcceptanceTest(t *testing.T, output, testName string) {
 		"::set-output name=major::",
 		"::set-output name=minor::",
 		"::set-output name=patch::",
+		"::set-output name=prerelease::",
+		"::set-output name=build-metadata::",
 		"::set-output name=increment-type::",
+		"::set-output name=increment-commits::",
 	}
 
 	for _, expectedOutput := range expectedOutputs {
@@ -427,6 +566,258 @@ func TestAcceptanceGetNextSemverOutputs(t *testing.T) {
 	}
 }
 
+// Test that auto resolves the increment from Conventional Commits since the current version tag
+func TestAcceptanceGetNextSemverAutoIncrement(t *testing.T) {
+	// Skip this test when running in GitHub Actions since outputs go to file instead of stdout
+	if os.Getenv("GITHUB_OUTPUT") != "" {
+		t.Skip("Skipping output format test in GitHub Actions environment where outputs go to file")
+	}
+
+	// Create temporary directory for building
+	tempBuildDir, err := os.MkdirTemp("", "build-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp build dir: %v", err)
+	}
+	defer os.RemoveAll(tempBuildDir)
+
+	// Build the binary in temp directory
+	binaryPath := filepath.Join(tempBuildDir, "get-next-semver")
+
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, "main.go")
+	if err := buildCmd.Run(); err != nil {
+		t.Fatalf("Failed to build binary: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		currentVersion  string
+		tag             string
+		commits         []string
+		expectedOutputs []string
+		expectedError   bool
+	}{
+		{
+			name:           "feat since the tag is a minor",
+			currentVersion: "v1.2.3",
+			tag:            "v1.2.3",
+			commits:        []string{"fix: handle empty tags", "feat: add paging", "docs: update README"},
+			expectedOutputs: []string{
+				"Next version: v1.3.0 (minor increment)",
+				"::set-output name=increment-type::minor",
+				`"subject":"feat: add paging"`,
+			},
+		},
+		{
+			name:           "breaking change is a major",
+			currentVersion: "v1.2.3",
+			tag:            "v1.2.3",
+			commits:        []string{"feat: add paging", "refactor!: rename outputs"},
+			expectedOutputs: []string{
+				"Next version: v2.0.0 (major increment)",
+				`"subject":"refactor!: rename outputs"`,
+			},
+		},
+		{
+			name:           "breaking change before 1.0.0 is a minor",
+			currentVersion: "v0.4.1",
+			tag:            "v0.4.1",
+			commits:        []string{"refactor!: rename outputs"},
+			expectedOutputs: []string{
+				"Next version: v0.5.0 (minor increment)",
+			},
+		},
+		{
+			name:           "no releasable commits",
+			currentVersion: "v1.2.3",
+			tag:            "v1.2.3",
+			commits:        []string{"docs: update README", "chore: bump deps"},
+			expectedOutputs: []string{
+				"Next version: v1.2.3 (none increment)",
+				"::set-output name=increment-commits::[]",
+			},
+		},
+		{
+			name:           "current version is not a tag",
+			currentVersion: "v9.9.9",
+			commits:        []string{"feat: add paging"},
+			expectedError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a git repository with the tag before the new commits
+			tempDir, err := os.MkdirTemp("", "git-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			git := func(args ...string) {
+				cmd := exec.Command("git", args...)
+				cmd.Dir = tempDir
+				if output, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+				}
+			}
+
+			git("init")
+			git("config", "user.email", "test@example.com")
+			git("config", "user.name", "Test User")
+			git("commit", "--allow-empty", "-m", "chore: initial commit")
+			if tt.tag != "" {
+				git("tag", tt.tag)
+			}
+			for _, message := range tt.commits {
+				git("commit", "--allow-empty", "-m", message)
+			}
+
+			os.Setenv("INPUT_CURRENT_VERSION", tt.currentVersion)
+			os.Setenv("INPUT_INCREMENT", "auto")
+			os.Setenv("INPUT_PREFIX", "v")
+			defer func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREFIX")
+			}()
+
+			// Run the binary in the git repository
+			cmd := exec.Command(binaryPath)
+			cmd.Dir = tempDir
+			output, err := cmd.CombinedOutput()
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected error but command succeeded\nOutput: %s", output)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v\nOutput: %s", err, output)
+				return
+			}
+
+			outputStr := string(output)
+			for _, expectedOutput := range tt.expectedOutputs {
+				if !contains(outputStr, expectedOutput) {
+					t.Errorf("Expected output to contain %q, got: %s", expectedOutput, outputStr)
+				}
+			}
+		})
+	}
+}
+
+// Test that build metadata is rendered from the commit and run into the version
+func TestAcceptanceGetNextSemverBuildMetadata(t *testing.T) {
+	// Skip this test when running in GitHub Actions since outputs go to file instead of stdout
+	if os.Getenv("GITHUB_OUTPUT") != "" {
+		t.Skip("Skipping output format test in GitHub Actions environment where outputs go to file")
+	}
+
+	// Create temporary directory for building
+	tempBuildDir, err := os.MkdirTemp("", "build-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp build dir: %v", err)
+	}
+	defer os.RemoveAll(tempBuildDir)
+
+	// Build the binary in temp directory
+	binaryPath := filepath.Join(tempBuildDir, "get-next-semver")
+
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, "main.go")
+	if err := buildCmd.Run(); err != nil {
+		t.Fatalf("Failed to build binary: %v", err)
+	}
+
+	// Create a git repository with a single commit to render the SHA from
+	tempDir, err := os.MkdirTemp("", "git-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tempDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	cmd := exec.Command("git", "rev-parse", "--short=7", "HEAD")
+	cmd.Dir = tempDir
+	shortSHAOutput, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Failed to get short SHA: %v", err)
+	}
+	shortSHA := strings.TrimSpace(string(shortSHAOutput))
+
+	tests := []struct {
+		name            string
+		runNumber       string
+		expectedOutputs []string
+		expectedError   bool
+	}{
+		{
+			name:      "sha and run number",
+			runNumber: "42",
+			expectedOutputs: []string{
+				"Next version: v1.4.2+sha." + shortSHA + ".run.42 (patch increment)",
+				"::set-output name=version-core::1.4.2+sha." + shortSHA + ".run.42",
+				"::set-output name=build-metadata::sha." + shortSHA + ".run.42",
+			},
+		},
+		{
+			name:          "missing run number",
+			runNumber:     "",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("INPUT_CURRENT_VERSION", "v1.4.1")
+			os.Setenv("INPUT_BUILD_METADATA", "sha.{{.ShortSHA}}.run.{{.RunNumber}}")
+			os.Setenv("GITHUB_RUN_NUMBER", tt.runNumber)
+			defer func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_BUILD_METADATA")
+				os.Unsetenv("GITHUB_RUN_NUMBER")
+			}()
+
+			// Run the binary in the git repository
+			cmd := exec.Command(binaryPath)
+			cmd.Dir = tempDir
+			output, err := cmd.CombinedOutput()
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected error but command succeeded\nOutput: %s", output)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v\nOutput: %s", err, output)
+				return
+			}
+
+			outputStr := string(output)
+			for _, expectedOutput := range tt.expectedOutputs {
+				if !contains(outputStr, expectedOutput) {
+					t.Errorf("Expected output to contain %q, got: %s", expectedOutput, outputStr)
+				}
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
\ No newline at end of file
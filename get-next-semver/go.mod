@@ -2,4 +2,7 @@ module github.com/half-ogre-games/hog-actions/get-next-semver
 
 go 1.24.3
 
-require github.com/half-ogre/go-kit v0.2.0
+require (
+	github.com/half-ogre-games/hog-actions/internal/semveractions v0.0.0-20250721173534-824962fd61a1
+	github.com/half-ogre/go-kit v0.2.0
+)
@@ -1,32 +1,39 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/half-ogre/go-kit/actionskit"
 	"github.com/half-ogre/go-kit/versionkit"
+	"github.com/half-ogre-games/hog-actions/internal/semveractions"
 )
 
 // Config holds the configuration for the get-next-semver action
 type Config struct {
 	CurrentVersion   string
-	IncrementMajor   bool
-	IncrementMinor   bool
+	Increment        string
+	Preid            string
 	Prefix          string
+	Commit          string
+	BuildMetadata   string // Template rendered into the version's build metadata
 }
 
 // Result holds the result of the get-next-semver action
 type Result struct {
-	Version       string
-	VersionCore   string
-	Major         int
-	Minor         int
-	Patch         int
-	IncrementType string
-	Success       bool
-	Error         error
+	Version          string
+	VersionCore      string
+	Major            int
+	Minor            int
+	Patch            int
+	Prerelease       string
+	BuildMetadata    string
+	IncrementType    string
+	IncrementCommits []semveractions.Commit // Commits that caused an auto increment
+	Success          bool
+	Error            error
 }
 
 func main() {
@@ -43,6 +50,12 @@ func main() {
 	}
 
 	// Output results
+	if config.Increment == "auto" {
+		actionskit.Info(fmt.Sprintf("Auto increment resolved to %s from %d commit(s)", result.IncrementType, len(result.IncrementCommits)))
+		for _, commit := range result.IncrementCommits {
+			actionskit.Info(fmt.Sprintf("  %s %s", commit.SHA, commit.Subject))
+		}
+	}
 	actionskit.Info(fmt.Sprintf("Next version: %s (%s increment)", result.Version, result.IncrementType))
 
 	// Set outputs for GitHub Actions
@@ -76,11 +89,41 @@ func main() {
 		os.Exit(1)
 	}
 
+	err = actionskit.SetOutput("prerelease", result.Prerelease)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set prerelease output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("build-metadata", result.BuildMetadata)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set build-metadata output: %v", err))
+		os.Exit(1)
+	}
+
 	err = actionskit.SetOutput("increment-type", result.IncrementType)
 	if err != nil {
 		actionskit.Error(fmt.Sprintf("Failed to set increment-type output: %v", err))
 		os.Exit(1)
 	}
+
+	// Encode no commits as an empty array rather than null
+	incrementCommits := result.IncrementCommits
+	if incrementCommits == nil {
+		incrementCommits = []semveractions.Commit{}
+	}
+
+	incrementCommitsJSON, err := json.Marshal(incrementCommits)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to encode increment-commits: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("increment-commits", string(incrementCommitsJSON))
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set increment-commits output: %v", err))
+		os.Exit(1)
+	}
 }
 
 // getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
@@ -90,12 +133,14 @@ func getConfigFromEnvironment() (*Config, error) {
 		return nil, fmt.Errorf("current-version input is required")
 	}
 
-	incrementMajor := actionskit.GetInput("increment-major") == "true"
-	incrementMinor := actionskit.GetInput("increment-minor") == "true"
+	increment, err := semveractions.GetIncrement()
+	if err != nil {
+		return nil, err
+	}
 
-	// Validate that only one increment type is specified
-	if incrementMajor && incrementMinor {
-		return nil, fmt.Errorf("cannot increment both major and minor versions simultaneously")
+	preid := actionskit.GetInput("preid")
+	if err := semveractions.ValidatePreid(preid); err != nil {
+		return nil, err
 	}
 
 	prefix := actionskit.GetInput("prefix")
@@ -105,11 +150,25 @@ func getConfigFromEnvironment() (*Config, error) {
 		prefix = "v"
 	}
 
+	commit := actionskit.GetInput("commit")
+	if commit == "" {
+		commit = "HEAD"
+	}
+
+	buildMetadata := actionskit.GetInput("build-metadata")
+	if buildMetadata != "" {
+		if err := semveractions.ValidateBuildMetadataTemplate(buildMetadata); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Config{
 		CurrentVersion: currentVersion,
-		IncrementMajor: incrementMajor,
-		IncrementMinor: incrementMinor,
+		Increment:      increment,
+		Preid:          preid,
 		Prefix:        prefix,
+		Commit:        commit,
+		BuildMetadata: buildMetadata,
 	}, nil
 }
 
@@ -127,44 +186,48 @@ func run(config *Config) *Result {
 		return result
 	}
 
-	// Calculate next version
-	nextMajor := currentSemver.MajorVersion
-	nextMinor := currentSemver.MinorVersion
-	nextPatch := currentSemver.PatchVersion
-	incrementType := "patch" // default
-
-	if config.IncrementMajor {
-		nextMajor++
-		nextMinor = 0
-		nextPatch = 0
-		incrementType = "major"
-	} else if config.IncrementMinor {
-		nextMinor++
-		nextPatch = 0
-		incrementType = "minor"
-	} else {
-		nextPatch++
-		incrementType = "patch"
-	}
-
-	// Create next version (always remove pre-release and build metadata for releases)
-	nextVersion := versionkit.SemanticVersion{
-		MajorVersion:      nextMajor,
-		MinorVersion:      nextMinor,
-		PatchVersion:      nextPatch,
-		PreReleaseVersion: "", // Always clear for release versions
-		BuildMetadata:     "", // Always clear for release versions
-	}
-
-	nextVersionCore := nextVersion.String()
-	nextVersionWithPrefix := config.Prefix + nextVersionCore
-
-	result.Version = nextVersionWithPrefix
-	result.VersionCore = nextVersionCore
-	result.Major = int(nextMajor)
-	result.Minor = int(nextMinor)
-	result.Patch = int(nextPatch)
-	result.IncrementType = incrementType
+	// Resolve an auto increment from the Conventional Commits since the current version
+	increment := config.Increment
+	if increment == "auto" {
+		commits, err := semveractions.GetCommitsSince(config.CurrentVersion, config.Commit)
+		if err != nil {
+			result.Error = fmt.Errorf("error getting commits since %s: %v", config.CurrentVersion, err)
+			return result
+		}
+
+		increment, result.IncrementCommits = semveractions.AutoIncrement(commits, currentSemver)
+	}
+
+	// Calculate next version using the shared semver-aware increment logic
+	nextVersion, err := semveractions.IncrementVersion(currentSemver, increment, config.Preid)
+	if err != nil {
+		result.Error = fmt.Errorf("error incrementing version: %v", err)
+		return result
+	}
+
+	// Render the build metadata for the commit, e.g. sha.abc1234.run.42
+	if config.BuildMetadata != "" {
+		data, err := semveractions.GetBuildMetadata(config.Commit)
+		if err != nil {
+			result.Error = fmt.Errorf("error getting build metadata: %v", err)
+			return result
+		}
+
+		nextVersion.BuildMetadata, err = semveractions.RenderBuildMetadata(config.BuildMetadata, *data)
+		if err != nil {
+			result.Error = fmt.Errorf("error rendering build metadata: %v", err)
+			return result
+		}
+	}
+
+	result.Version = semveractions.FormatVersionWithPrefix(nextVersion, config.Prefix)
+	result.VersionCore = semveractions.FormatVersionWithPrefix(nextVersion, "")
+	result.Major = int(nextVersion.MajorVersion)
+	result.Minor = int(nextVersion.MinorVersion)
+	result.Patch = int(nextVersion.PatchVersion)
+	result.Prerelease = nextVersion.PreReleaseVersion
+	result.BuildMetadata = nextVersion.BuildMetadata
+	result.IncrementType = increment
 	result.Success = true
 	return result
 }
\ No newline at end of file
@@ -27,9 +27,9 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 			expectError: false,
 			expected: &Config{
 				CurrentVersion: "v1.2.3",
-				IncrementMajor: false,
-				IncrementMinor: false,
+				Increment:      "patch",
 				Prefix:        "v",
+				Commit:         "HEAD",
 			},
 		},
 		{
@@ -47,9 +47,9 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 			expectError: false,
 			expected: &Config{
 				CurrentVersion: "1.2.3",
-				IncrementMajor: true,
-				IncrementMinor: false,
+				Increment:      "major",
 				Prefix:        "",
+				Commit:         "HEAD",
 			},
 		},
 		{
@@ -65,9 +65,9 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 			expectError: false,
 			expected: &Config{
 				CurrentVersion: "v2.5.8",
-				IncrementMajor: false,
-				IncrementMinor: true,
+				Increment:      "minor",
 				Prefix:        "v",
+				Commit:         "HEAD",
 			},
 		},
 		{
@@ -96,6 +96,161 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 			expectError: true,
 			errorMsg:   "cannot increment both major and minor versions simultaneously",
 		},
+		{
+			name: "prerelease increment with preid",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.3.0-rc.1")
+				os.Setenv("INPUT_INCREMENT", "prerelease")
+				os.Setenv("INPUT_PREID", "rc")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_PREID")
+			},
+			expectError: false,
+			expected: &Config{
+				CurrentVersion: "v1.3.0-rc.1",
+				Increment:      "prerelease",
+				Preid:          "rc",
+				Prefix:         "v",
+				Commit:         "HEAD",
+			},
+		},
+		{
+			name: "release increment",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.3.0-rc.2")
+				os.Setenv("INPUT_INCREMENT", "release")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+			},
+			expectError: false,
+			expected: &Config{
+				CurrentVersion: "v1.3.0-rc.2",
+				Increment:      "release",
+				Prefix:         "v",
+				Commit:         "HEAD",
+			},
+		},
+		{
+			name: "increment input",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "Minor")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+			},
+			expectError: false,
+			expected: &Config{
+				CurrentVersion: "v1.2.3",
+				Increment:      "minor",
+				Prefix:         "v",
+				Commit:         "HEAD",
+			},
+		},
+		{
+			name: "none increment",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "none")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+			},
+			expectError: false,
+			expected: &Config{
+				CurrentVersion: "v1.2.3",
+				Increment:      "none",
+				Prefix:         "v",
+				Commit:         "HEAD",
+			},
+		},
+		{
+			name: "unknown increment",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "huge")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+			},
+			expectError: true,
+			errorMsg:    `increment must be one of major, minor, patch, premajor, preminor, prepatch, prerelease, release, none, auto, got "huge"`,
+		},
+		{
+			name: "increment with deprecated increment-major",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "minor")
+				os.Setenv("INPUT_INCREMENT_MAJOR", "true")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_INCREMENT_MAJOR")
+			},
+			expectError: true,
+			errorMsg:    "increment cannot be combined with the deprecated increment-major or increment-minor inputs",
+		},
+		{
+			name: "build metadata template",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.4.1")
+				os.Setenv("INPUT_BUILD_METADATA", "sha.{{.ShortSHA}}.run.{{.RunNumber}}")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_BUILD_METADATA")
+			},
+			expectError: false,
+			expected: &Config{
+				CurrentVersion: "v1.4.1",
+				Increment:      "patch",
+				Prefix:         "v",
+				Commit:         "HEAD",
+				BuildMetadata:  "sha.{{.ShortSHA}}.run.{{.RunNumber}}",
+			},
+		},
+		{
+			name: "invalid build metadata template",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.4.1")
+				os.Setenv("INPUT_BUILD_METADATA", "sha/{{.ShortSHA}}")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_BUILD_METADATA")
+			},
+			expectError: true,
+			errorMsg:    `build metadata must be dot-separated alphanumeric identifiers, got "sha/0123456"`,
+		},
+		{
+			name: "auto increment with commit",
+			setupEnv: func() {
+				os.Setenv("INPUT_CURRENT_VERSION", "v1.2.3")
+				os.Setenv("INPUT_INCREMENT", "auto")
+				os.Setenv("INPUT_COMMIT", "main")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_CURRENT_VERSION")
+				os.Unsetenv("INPUT_INCREMENT")
+				os.Unsetenv("INPUT_COMMIT")
+			},
+			expectError: false,
+			expected: &Config{
+				CurrentVersion: "v1.2.3",
+				Increment:      "auto",
+				Prefix:         "v",
+				Commit:         "main",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -122,15 +277,21 @@ func TestGetConfigFromEnvironment(t *testing.T) {
 			if config.CurrentVersion != tt.expected.CurrentVersion {
 				t.Errorf("CurrentVersion = %q, want %q", config.CurrentVersion, tt.expected.CurrentVersion)
 			}
-			if config.IncrementMajor != tt.expected.IncrementMajor {
-				t.Errorf("IncrementMajor = %v, want %v", config.IncrementMajor, tt.expected.IncrementMajor)
+			if config.Increment != tt.expected.Increment {
+				t.Errorf("Increment = %q, want %q", config.Increment, tt.expected.Increment)
 			}
-			if config.IncrementMinor != tt.expected.IncrementMinor {
-				t.Errorf("IncrementMinor = %v, want %v", config.IncrementMinor, tt.expected.IncrementMinor)
+			if config.Preid != tt.expected.Preid {
+				t.Errorf("Preid = %q, want %q", config.Preid, tt.expected.Preid)
 			}
 			if config.Prefix != tt.expected.Prefix {
 				t.Errorf("Prefix = %q, want %q", config.Prefix, tt.expected.Prefix)
 			}
+			if config.Commit != tt.expected.Commit {
+				t.Errorf("Commit = %q, want %q", config.Commit, tt.expected.Commit)
+			}
+			if config.BuildMetadata != tt.expected.BuildMetadata {
+				t.Errorf("BuildMetadata = %q, want %q", config.BuildMetadata, tt.expected.BuildMetadata)
+			}
 		})
 	}
 }
@@ -146,8 +307,7 @@ func TestRun(t *testing.T) {
 			name: "patch increment basic",
 			config: &Config{
 				CurrentVersion: "v1.2.3",
-				IncrementMajor: false,
-				IncrementMinor: false,
+				Increment:      "patch",
 				Prefix:        "v",
 			},
 			expectedResult: &Result{
@@ -164,8 +324,7 @@ func TestRun(t *testing.T) {
 			name: "minor increment",
 			config: &Config{
 				CurrentVersion: "v1.2.3",
-				IncrementMajor: false,
-				IncrementMinor: true,
+				Increment:      "minor",
 				Prefix:        "v",
 			},
 			expectedResult: &Result{
@@ -182,8 +341,7 @@ func TestRun(t *testing.T) {
 			name: "major increment",
 			config: &Config{
 				CurrentVersion: "v1.2.3",
-				IncrementMajor: true,
-				IncrementMinor: false,
+				Increment:      "major",
 				Prefix:        "v",
 			},
 			expectedResult: &Result{
@@ -197,29 +355,87 @@ func TestRun(t *testing.T) {
 			},
 		},
 		{
-			name: "version with prerelease - patch increment removes prerelease",
+			name: "version with prerelease - patch increment promotes prerelease",
 			config: &Config{
 				CurrentVersion: "v1.2.3-alpha.1",
-				IncrementMajor: false,
-				IncrementMinor: false,
+				Increment:      "patch",
 				Prefix:        "v",
 			},
 			expectedResult: &Result{
-				Version:       "v1.2.4",
-				VersionCore:   "1.2.4",
+				Version:       "v1.2.3",
+				VersionCore:   "1.2.3",
 				Major:         1,
 				Minor:         2,
-				Patch:         4,
+				Patch:         3,
 				IncrementType: "patch",
 				Success:       true,
 			},
 		},
+		{
+			name: "minor prerelease - minor increment promotes prerelease",
+			config: &Config{
+				CurrentVersion: "v1.3.0-rc.2",
+				Increment:      "minor",
+				Prefix:        "v",
+			},
+			expectedResult: &Result{
+				Version:       "v1.3.0",
+				VersionCore:   "1.3.0",
+				Major:         1,
+				Minor:         3,
+				Patch:         0,
+				IncrementType: "minor",
+				Success:       true,
+			},
+		},
+		{
+			name: "minor prerelease - major increment bumps past it",
+			config: &Config{
+				CurrentVersion: "v1.3.0-rc.2",
+				Increment:      "major",
+				Prefix:        "v",
+			},
+			expectedResult: &Result{
+				Version:       "v2.0.0",
+				VersionCore:   "2.0.0",
+				Major:         2,
+				Minor:         0,
+				Patch:         0,
+				IncrementType: "major",
+				Success:       true,
+			},
+		},
+		{
+			name: "release promotes prerelease",
+			config: &Config{
+				CurrentVersion: "v1.3.0-rc.2+build.5",
+				Increment:      "release",
+				Prefix:         "v",
+			},
+			expectedResult: &Result{
+				Version:       "v1.3.0",
+				VersionCore:   "1.3.0",
+				Major:         1,
+				Minor:         3,
+				Patch:         0,
+				IncrementType: "release",
+				Success:       true,
+			},
+		},
+		{
+			name: "release of a release version",
+			config: &Config{
+				CurrentVersion: "v1.3.0",
+				Increment:      "release",
+				Prefix:         "v",
+			},
+			expectError: true,
+		},
 		{
 			name: "version with build metadata - removed in result",
 			config: &Config{
 				CurrentVersion: "v1.2.3+build.456",
-				IncrementMajor: false,
-				IncrementMinor: false,
+				Increment:      "patch",
 				Prefix:        "v",
 			},
 			expectedResult: &Result{
@@ -236,8 +452,7 @@ func TestRun(t *testing.T) {
 			name: "no prefix",
 			config: &Config{
 				CurrentVersion: "1.2.3",
-				IncrementMajor: false,
-				IncrementMinor: false,
+				Increment:      "patch",
 				Prefix:        "",
 			},
 			expectedResult: &Result{
@@ -254,8 +469,7 @@ func TestRun(t *testing.T) {
 			name: "zero version major increment",
 			config: &Config{
 				CurrentVersion: "v0.1.0",
-				IncrementMajor: true,
-				IncrementMinor: false,
+				Increment:      "major",
 				Prefix:        "v",
 			},
 			expectedResult: &Result{
@@ -268,12 +482,85 @@ func TestRun(t *testing.T) {
 				Success:       true,
 			},
 		},
+		{
+			name: "preminor starts a release candidate",
+			config: &Config{
+				CurrentVersion: "v1.2.3",
+				Increment:      "preminor",
+				Preid:          "rc",
+				Prefix:         "v",
+			},
+			expectedResult: &Result{
+				Version:       "v1.3.0-rc.0",
+				VersionCore:   "1.3.0-rc.0",
+				Major:         1,
+				Minor:         3,
+				Patch:         0,
+				Prerelease:    "rc.0",
+				IncrementType: "preminor",
+				Success:       true,
+			},
+		},
+		{
+			name: "prerelease increments numerically",
+			config: &Config{
+				CurrentVersion: "v1.3.0-rc.9",
+				Increment:      "prerelease",
+				Preid:          "rc",
+				Prefix:         "v",
+			},
+			expectedResult: &Result{
+				Version:       "v1.3.0-rc.10",
+				VersionCore:   "1.3.0-rc.10",
+				Major:         1,
+				Minor:         3,
+				Patch:         0,
+				Prerelease:    "rc.10",
+				IncrementType: "prerelease",
+				Success:       true,
+			},
+		},
+		{
+			name: "premajor without preid",
+			config: &Config{
+				CurrentVersion: "1.2.3",
+				Increment:      "premajor",
+				Prefix:         "",
+			},
+			expectedResult: &Result{
+				Version:       "2.0.0-0",
+				VersionCore:   "2.0.0-0",
+				Major:         2,
+				Minor:         0,
+				Patch:         0,
+				Prerelease:    "0",
+				IncrementType: "premajor",
+				Success:       true,
+			},
+		},
+		{
+			name: "none keeps the current version",
+			config: &Config{
+				CurrentVersion: "v1.3.0-rc.1+build.5",
+				Increment:      "none",
+				Prefix:         "v",
+			},
+			expectedResult: &Result{
+				Version:       "v1.3.0-rc.1",
+				VersionCore:   "1.3.0-rc.1",
+				Major:         1,
+				Minor:         3,
+				Patch:         0,
+				Prerelease:    "rc.1",
+				IncrementType: "none",
+				Success:       true,
+			},
+		},
 		{
 			name: "invalid version format",
 			config: &Config{
 				CurrentVersion: "invalid-version",
-				IncrementMajor: false,
-				IncrementMinor: false,
+				Increment:      "patch",
 				Prefix:        "",
 			},
 			expectError: true,
@@ -311,6 +598,9 @@ func TestRun(t *testing.T) {
 			if result.Patch != tt.expectedResult.Patch {
 				t.Errorf("Patch = %d, want %d", result.Patch, tt.expectedResult.Patch)
 			}
+			if result.Prerelease != tt.expectedResult.Prerelease {
+				t.Errorf("Prerelease = %q, want %q", result.Prerelease, tt.expectedResult.Prerelease)
+			}
 			if result.IncrementType != tt.expectedResult.IncrementType {
 				t.Errorf("IncrementType = %q, want %q", result.IncrementType, tt.expectedResult.IncrementType)
 			}
@@ -1,10 +1,16 @@
 package semveractions
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"os/exec"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/half-ogre/go-kit/actionskit"
 	"github.com/half-ogre/go-kit/versionkit"
@@ -35,6 +41,9 @@ type TagWithVersion struct {
 	Version versionkit.SemanticVersion
 }
 
+// preidPattern matches dot-separated semver prerelease identifiers such as "rc" or "beta.ios"
+var preidPattern = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+
 // GetSemverPrefix reads prefix from GitHub Actions input with fallback to "v"
 func GetSemverPrefix() string {
 	prefix := actionskit.GetInput("prefix")
@@ -120,6 +129,23 @@ func GetAllTags() ([]string, error) {
 	return validTags, nil
 }
 
+// GetMergedTags retrieves the git tags reachable from commit, the same candidates
+// git describe considers
+func GetMergedTags(commit string) ([]string, error) {
+	output, err := exec.Command("git", "tag", "--merged", commit).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git tag --merged %s: %v", commit, err)
+	}
+
+	tags := []string{}
+	for _, tag := range strings.Split(string(output), "\n") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
 // FilterTagsByPrefix filters tags that match the given prefix
 func FilterTagsByPrefix(tags []string, prefix string) []string {
 	var filteredTags []string
@@ -211,11 +237,117 @@ func SetVersionOutputs(result *SemverResult, incrementType string) error {
 	return nil
 }
 
-// IncrementVersion calculates next version based on increment type
-func IncrementVersion(current *versionkit.SemanticVersion, incrementMajor, incrementMinor bool) (*versionkit.SemanticVersion, string, error) {
-	// Validate increment flags
+// SetMultilineOutput sets an output whose value may span lines, using the
+// GITHUB_OUTPUT heredoc syntax that actionskit.SetOutput does not support
+func SetMultilineOutput(name, value string) error {
+	outputFile := os.Getenv("GITHUB_OUTPUT")
+	if outputFile == "" || !strings.Contains(value, "\n") {
+		return actionskit.SetOutput(name, value)
+	}
+
+	delimiterBytes := make([]byte, 8)
+	if _, err := rand.Read(delimiterBytes); err != nil {
+		return fmt.Errorf("error generating output delimiter: %v", err)
+	}
+	delimiter := "ghadelimiter_" + hex.EncodeToString(delimiterBytes)
+
+	f, err := os.OpenFile(outputFile, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %v", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s<<%s\n%s\n%s\n", name, delimiter, value, delimiter)
+	if err != nil {
+		return fmt.Errorf("error writing to output file: %v", err)
+	}
+
+	return nil
+}
+
+// IncrementTypes lists the values accepted by the increment input
+var IncrementTypes = []string{"major", "minor", "patch", "premajor", "preminor", "prepatch", "prerelease", "release", "none", "auto"}
+
+// GetIncrement reads the increment input, falling back to the deprecated
+// increment-major and increment-minor inputs with a warning. It defaults to
+// "patch" when nothing is set.
+func GetIncrement() (string, error) {
+	increment := strings.ToLower(strings.TrimSpace(actionskit.GetInput("increment")))
+
+	legacy, err := LegacyIncrement(
+		strings.EqualFold(actionskit.GetInput("increment-major"), "true"),
+		strings.EqualFold(actionskit.GetInput("increment-minor"), "true"),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if legacy != "" {
+		if increment != "" {
+			return "", fmt.Errorf("increment cannot be combined with the deprecated increment-major or increment-minor inputs")
+		}
+		actionskit.Warning(fmt.Sprintf("increment-major and increment-minor are deprecated; use increment: %s instead", legacy))
+		increment = legacy
+	}
+
+	if increment == "" {
+		increment = "patch"
+	}
+
+	return increment, ValidateIncrement(increment)
+}
+
+// LegacyIncrement maps the deprecated increment inputs to an increment type,
+// returning an empty string when neither of them is set
+func LegacyIncrement(incrementMajor, incrementMinor bool) (string, error) {
 	if incrementMajor && incrementMinor {
-		return nil, "", fmt.Errorf("cannot increment both major and minor versions simultaneously")
+		return "", fmt.Errorf("cannot increment both major and minor versions simultaneously")
+	}
+
+	switch {
+	case incrementMajor:
+		return "major", nil
+	case incrementMinor:
+		return "minor", nil
+	}
+	return "", nil
+}
+
+// ValidateIncrement ensures the increment is one of IncrementTypes
+func ValidateIncrement(increment string) error {
+	for _, incrementType := range IncrementTypes {
+		if increment == incrementType {
+			return nil
+		}
+	}
+	return fmt.Errorf("increment must be one of %s, got %q", strings.Join(IncrementTypes, ", "), increment)
+}
+
+// ValidatePreid ensures a preid is made of valid prerelease identifiers
+func ValidatePreid(preid string) error {
+	if preid != "" && !preidPattern.MatchString(preid) {
+		return fmt.Errorf("preid must be dot-separated alphanumeric identifiers, got %q", preid)
+	}
+	return nil
+}
+
+// IncrementVersion calculates the next version for an increment type. Bumps are
+// semver-aware: a prerelease already precedes its release, so a bump that would
+// land on that release promotes it instead (1.3.0-rc.2 with a minor bump is 1.3.0).
+// "none" returns the current version without build metadata, and "auto" must be
+// resolved to a concrete increment by the caller first.
+func IncrementVersion(current *versionkit.SemanticVersion, increment, preid string) (*versionkit.SemanticVersion, error) {
+	if err := ValidateIncrement(increment); err != nil {
+		return nil, err
+	}
+
+	switch increment {
+	case "premajor", "preminor", "prepatch", "prerelease":
+		return IncrementPrerelease(current, increment, preid)
+	case "release":
+		return ReleaseVersion(current)
+	case "auto":
+		return nil, fmt.Errorf("auto increment must be resolved before calculating the next version")
 	}
 
 	// Create a copy of the current version to avoid modifying the original
@@ -227,30 +359,603 @@ func IncrementVersion(current *versionkit.SemanticVersion, incrementMajor, incre
 		BuildMetadata:     "",
 	}
 
-	var incrementType string
+	isPrerelease := current.PreReleaseVersion != ""
+
+	switch increment {
+	case "major":
+		if !isPrerelease || current.MinorVersion != 0 || current.PatchVersion != 0 {
+			newVersion.MajorVersion++
+			newVersion.MinorVersion = 0
+			newVersion.PatchVersion = 0
+		}
+	case "minor":
+		if !isPrerelease || current.PatchVersion != 0 {
+			newVersion.MinorVersion++
+			newVersion.PatchVersion = 0
+		}
+	case "patch":
+		if !isPrerelease {
+			newVersion.PatchVersion++
+		}
+	case "none":
+		newVersion.PreReleaseVersion = current.PreReleaseVersion
+	}
+
+	return &newVersion, nil
+}
+
+// ReleaseVersion promotes a prerelease to its final version by dropping the
+// prerelease and build metadata, so 1.3.0-rc.2 becomes 1.3.0
+func ReleaseVersion(current *versionkit.SemanticVersion) (*versionkit.SemanticVersion, error) {
+	if current.PreReleaseVersion == "" {
+		return nil, fmt.Errorf("cannot release %s because it is not a prerelease", current.String())
+	}
+
+	return &versionkit.SemanticVersion{
+		MajorVersion: current.MajorVersion,
+		MinorVersion: current.MinorVersion,
+		PatchVersion: current.PatchVersion,
+	}, nil
+}
+
+// isPrereleaseIncrement reports whether an increment type produces a prerelease
+func isPrereleaseIncrement(increment string) bool {
+	switch increment {
+	case "premajor", "preminor", "prepatch", "prerelease":
+		return true
+	}
+	return false
+}
+
+// IncrementPrerelease calculates the next prerelease version. premajor, preminor and
+// prepatch bump that component and start a new prerelease at preid.0. prerelease
+// increments the existing prerelease, or behaves like prepatch on a release version.
+func IncrementPrerelease(current *versionkit.SemanticVersion, incrementType, preid string) (*versionkit.SemanticVersion, error) {
+	if !isPrereleaseIncrement(incrementType) {
+		return nil, fmt.Errorf("prerelease increment must be premajor, preminor, prepatch or prerelease, got %q", incrementType)
+	}
+	if err := ValidatePreid(preid); err != nil {
+		return nil, err
+	}
 
-	if incrementMajor {
+	// Create a copy of the current version; build metadata never carries over
+	newVersion := versionkit.SemanticVersion{
+		MajorVersion:      current.MajorVersion,
+		MinorVersion:      current.MinorVersion,
+		PatchVersion:      current.PatchVersion,
+		PreReleaseVersion: "",
+		BuildMetadata:     "",
+	}
+
+	switch incrementType {
+	case "premajor":
 		newVersion.MajorVersion++
 		newVersion.MinorVersion = 0
 		newVersion.PatchVersion = 0
-		incrementType = "major"
-	} else if incrementMinor {
+		newVersion.PreReleaseVersion = firstPrerelease(preid)
+	case "preminor":
 		newVersion.MinorVersion++
 		newVersion.PatchVersion = 0
-		incrementType = "minor"
-	} else {
-		// Default to patch increment
+		newVersion.PreReleaseVersion = firstPrerelease(preid)
+	case "prepatch":
 		newVersion.PatchVersion++
-		incrementType = "patch"
+		newVersion.PreReleaseVersion = firstPrerelease(preid)
+	case "prerelease":
+		if current.PreReleaseVersion == "" {
+			newVersion.PatchVersion++
+			newVersion.PreReleaseVersion = firstPrerelease(preid)
+		} else {
+			newVersion.PreReleaseVersion = nextPrerelease(current.PreReleaseVersion, preid)
+		}
 	}
 
-	return &newVersion, incrementType, nil
+	return &newVersion, nil
 }
 
-// ValidateIncrementFlags ensures only one increment type is specified
-func ValidateIncrementFlags(incrementMajor, incrementMinor bool) error {
-	if incrementMajor && incrementMinor {
-		return fmt.Errorf("cannot increment both major and minor versions simultaneously")
+// firstPrerelease returns the initial prerelease for a preid, e.g. "rc.0", or "0" without one
+func firstPrerelease(preid string) string {
+	if preid == "" {
+		return "0"
+	}
+	return preid + ".0"
+}
+
+// nextPrerelease increments the last numeric identifier of a prerelease, so rc.9
+// becomes rc.10. A different preid restarts the sequence at preid.0, and a prerelease
+// without a numeric identifier gains one.
+func nextPrerelease(prerelease, preid string) string {
+	if preid != "" && prerelease != preid && !strings.HasPrefix(prerelease, preid+".") {
+		return firstPrerelease(preid)
+	}
+
+	identifiers := strings.Split(prerelease, ".")
+	for i := len(identifiers) - 1; i >= 0; i-- {
+		number, err := strconv.ParseUint(identifiers[i], 10, 64)
+		if err == nil {
+			identifiers[i] = strconv.FormatUint(number+1, 10)
+			return strings.Join(identifiers, ".")
+		}
+	}
+
+	return prerelease + ".0"
+}
+
+// Commit is a commit considered when resolving an auto increment
+type Commit struct {
+	SHA         string `json:"sha"`
+	Subject     string `json:"subject"`
+	Body        string `json:"-"`
+	Author      string `json:"-"`
+	AuthorEmail string `json:"-"`
+}
+
+// ConventionalCommit is a commit message parsed per the Conventional Commits spec
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+}
+
+// conventionalHeaderPattern matches a Conventional Commits header such as "feat(api)!: add paging"
+var conventionalHeaderPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: (.+)$`)
+
+// breakingFooterPattern matches a BREAKING CHANGE footer at the start of a body line
+var breakingFooterPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+
+// GetCommitsSince lists the commits reachable from commit but not from since,
+// newest first. An empty since lists the entire history of commit. Merge commits
+// are skipped so only the commits they bring in are counted.
+func GetCommitsSince(since, commit string) ([]Commit, error) {
+	revisionRange := commit
+	if since != "" {
+		revisionRange = since + ".." + commit
+	}
+
+	// Separate fields with US and commits with RS so multi-line bodies survive
+	cmd := exec.Command("git", "log", "--no-merges", "--format=%H%x1f%an%x1f%ae%x1f%s%x1f%b%x1e", revisionRange)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git log for %s: %v", revisionRange, err)
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, "\x1f", 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected git log output: %q", record)
+		}
+
+		commits = append(commits, Commit{
+			SHA:         fields[0],
+			Author:      fields[1],
+			AuthorEmail: fields[2],
+			Subject:     fields[3],
+			Body:        strings.TrimSpace(fields[4]),
+		})
+	}
+
+	return commits, nil
+}
+
+// GetAuthorEmails returns the set of author emails of the non-merge commits
+// reachable from commit, matching the commits GetCommitsSince lists
+func GetAuthorEmails(commit string) (map[string]bool, error) {
+	output, err := exec.Command("git", "log", "--no-merges", "--format=%ae", commit).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git log for %s: %v", commit, err)
+	}
+
+	emails := make(map[string]bool)
+	for _, email := range strings.Split(string(output), "\n") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails[strings.ToLower(email)] = true
+		}
+	}
+	return emails, nil
+}
+
+// CountCommitsSince counts the commits reachable from commit but not from since.
+// An empty since counts the entire history of commit.
+func CountCommitsSince(since, commit string) (int, error) {
+	revisionRange := commit
+	if since != "" {
+		revisionRange = since + ".." + commit
+	}
+
+	output, err := exec.Command("git", "rev-list", "--count", revisionRange).Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to run git rev-list for %s: %v", revisionRange, err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected git rev-list output: %q", string(output))
+	}
+	return count, nil
+}
+
+// ParseConventionalCommit parses a commit subject and body, reporting false when
+// the subject is not a Conventional Commits header
+func ParseConventionalCommit(subject, body string) (ConventionalCommit, bool) {
+	matches := conventionalHeaderPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if matches == nil {
+		return ConventionalCommit{}, false
+	}
+
+	return ConventionalCommit{
+		Type:        strings.ToLower(matches[1]),
+		Scope:       matches[2],
+		Breaking:    matches[3] == "!" || breakingFooterPattern.MatchString(body),
+		Description: matches[4],
+	}, true
+}
+
+// AutoIncrement resolves an auto increment from Conventional Commits: a breaking
+// change is major, feat is minor, and fix or perf is patch. While the major version
+// is 0, breaking changes are minor. It returns "none" when no commit calls for a
+// release, along with the commits that caused the chosen increment.
+func AutoIncrement(commits []Commit, current *versionkit.SemanticVersion) (string, []Commit) {
+	increments := []string{"none", "patch", "minor", "major"}
+	rank := 0
+	var causes []Commit
+
+	for _, commit := range commits {
+		conventional, ok := ParseConventionalCommit(commit.Subject, commit.Body)
+		if !ok {
+			continue
+		}
+
+		commitRank := 0
+		switch {
+		case conventional.Breaking && current.MajorVersion == 0:
+			commitRank = 2
+		case conventional.Breaking:
+			commitRank = 3
+		case conventional.Type == "feat":
+			commitRank = 2
+		case conventional.Type == "fix" || conventional.Type == "perf":
+			commitRank = 1
+		}
+
+		if commitRank == 0 || commitRank < rank {
+			continue
+		}
+		if commitRank > rank {
+			rank = commitRank
+			causes = nil
+		}
+		causes = append(causes, commit)
+	}
+
+	return increments[rank], causes
+}
+
+// BuildMetadata holds the fields available to a build-metadata template
+type BuildMetadata struct {
+	SHA        string
+	ShortSHA   string
+	RunNumber  string
+	RunID      string
+	RunAttempt string
+}
+
+// sampleBuildMetadata stands in for real values when validating a template up front
+var sampleBuildMetadata = BuildMetadata{
+	SHA:        "0123456789abcdef0123456789abcdef01234567",
+	ShortSHA:   "0123456",
+	RunNumber:  "1",
+	RunID:      "1",
+	RunAttempt: "1",
+}
+
+// GetBuildMetadata collects the build-metadata template fields for a commit from
+// git and the GitHub Actions run environment
+func GetBuildMetadata(commit string) (*BuildMetadata, error) {
+	output, err := exec.Command("git", "rev-parse", commit).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve commit %s: %v", commit, err)
+	}
+	sha := strings.TrimSpace(string(output))
+
+	shortSHA := sha
+	if len(shortSHA) > 7 {
+		shortSHA = shortSHA[:7]
+	}
+
+	return &BuildMetadata{
+		SHA:        sha,
+		ShortSHA:   shortSHA,
+		RunNumber:  os.Getenv("GITHUB_RUN_NUMBER"),
+		RunID:      os.Getenv("GITHUB_RUN_ID"),
+		RunAttempt: os.Getenv("GITHUB_RUN_ATTEMPT"),
+	}, nil
+}
+
+// ValidateBuildMetadataTemplate checks that a build-metadata template parses and,
+// rendered with sample values, produces valid build metadata
+func ValidateBuildMetadataTemplate(text string) error {
+	_, err := RenderBuildMetadata(text, sampleBuildMetadata)
+	return err
+}
+
+// RenderBuildMetadata executes a build-metadata template such as
+// "sha.{{.ShortSHA}}.run.{{.RunNumber}}" and validates the result
+func RenderBuildMetadata(text string, data BuildMetadata) (string, error) {
+	tmpl, err := template.New("build-metadata").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid build-metadata template: %v", err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("invalid build-metadata template: %v", err)
+	}
+
+	metadata := rendered.String()
+	if err := ValidateBuildMetadata(metadata); err != nil {
+		return "", err
+	}
+	return metadata, nil
+}
+
+// ValidateBuildMetadata ensures build metadata is made of non-empty, dot-separated
+// identifiers of ASCII alphanumerics and hyphens, per the semver spec
+func ValidateBuildMetadata(metadata string) error {
+	if !preidPattern.MatchString(metadata) {
+		return fmt.Errorf("build metadata must be dot-separated alphanumeric identifiers, got %q", metadata)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// ChangelogRelease describes the release a changelog section is generated for
+type ChangelogRelease struct {
+	Version       string // Version without prefix, used as the section heading
+	Tag           string
+	PreviousTag   string // Empty for a first release, which has no compare link
+	Date          string // Release date as YYYY-MM-DD
+	RepositoryURL string // Base URL for links, such as https://github.com/owner/repo; empty for no links
+}
+
+// changelogGroups orders the changelog groups and names their headings. Commits with
+// a hidden type are left out; other and non-conventional commits are grouped last.
+var changelogGroups = []struct {
+	Type    string
+	Heading string
+}{
+	{"breaking", "Breaking Changes"},
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"refactor", "Code Refactoring"},
+	{"other", "Other Changes"},
+}
+
+// hiddenChangelogTypes are Conventional Commits types left out of the changelog
+var hiddenChangelogTypes = map[string]bool{
+	"build": true,
+	"chore": true,
+	"ci":    true,
+	"style": true,
+	"test":  true,
+}
+
+// pullRequestReferencePattern matches a trailing pull request reference such as
+// "(#123)", which GitHub appends to squash-merged commit subjects
+var pullRequestReferencePattern = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+
+// changelogHeader starts a new CHANGELOG.md
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// GetRepositoryURL returns the web URL of the current GitHub repository from the
+// GitHub Actions environment, or "" when GITHUB_REPOSITORY is not set
+func GetRepositoryURL() string {
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		return ""
+	}
+
+	serverURL := os.Getenv("GITHUB_SERVER_URL")
+	if serverURL == "" {
+		serverURL = "https://github.com"
+	}
+	return strings.TrimSuffix(serverURL, "/") + "/" + repository
+}
+
+// GetCommitDate returns the committer date of a commit as YYYY-MM-DD
+func GetCommitDate(commit string) (string, error) {
+	output, err := exec.Command("git", "log", "-1", "--date=short", "--format=%cd", commit).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get date of commit %s: %v", commit, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ChangelogEntry is a commit as it appears in a changelog or release notes
+type ChangelogEntry struct {
+	Commit      Commit
+	Scope       string
+	Description string // Without the type, scope or pull request reference
+	PullRequest int    // Pull request from a squash merge's "(#123)" reference, 0 for none
+}
+
+// ChangelogGroup is the entries under one changelog heading
+type ChangelogGroup struct {
+	Type    string
+	Heading string
+	Entries []ChangelogEntry
+}
+
+// GroupChangelogEntries groups commits by Conventional Commits type in changelog
+// order, leaving out hidden types and empty groups
+func GroupChangelogEntries(commits []Commit) []ChangelogGroup {
+	entries := make(map[string][]ChangelogEntry)
+	for _, commit := range commits {
+		group, entry, ok := changelogEntry(commit)
+		if !ok {
+			continue
+		}
+		entries[group] = append(entries[group], entry)
+	}
+
+	var groups []ChangelogGroup
+	for _, group := range changelogGroups {
+		if len(entries[group.Type]) == 0 {
+			continue
+		}
+		groups = append(groups, ChangelogGroup{
+			Type:    group.Type,
+			Heading: group.Heading,
+			Entries: entries[group.Type],
+		})
+	}
+	return groups
+}
+
+// changelogEntry parses a commit into a changelog entry and returns the group it
+// belongs to, or false when its type is hidden
+func changelogEntry(commit Commit) (string, ChangelogEntry, bool) {
+	group := "other"
+	entry := ChangelogEntry{
+		Commit:      commit,
+		Description: strings.TrimSpace(commit.Subject),
+	}
+
+	if conventional, ok := ParseConventionalCommit(commit.Subject, commit.Body); ok {
+		switch {
+		case conventional.Breaking:
+			group = "breaking"
+		case hiddenChangelogTypes[conventional.Type]:
+			return "", ChangelogEntry{}, false
+		default:
+			for _, candidate := range changelogGroups {
+				if candidate.Type == conventional.Type {
+					group = conventional.Type
+				}
+			}
+		}
+		entry.Scope = conventional.Scope
+		entry.Description = conventional.Description
+	}
+
+	// Take a squash merge's pull request reference out of the description
+	if matches := pullRequestReferencePattern.FindStringSubmatch(entry.Description); matches != nil {
+		entry.PullRequest, _ = strconv.Atoi(matches[1])
+		entry.Description = pullRequestReferencePattern.ReplaceAllString(entry.Description, "")
+	}
+
+	return group, entry, true
+}
+
+// ShortSHA returns the abbreviated SHA of a commit
+func (c Commit) ShortSHA() string {
+	if len(c.SHA) > 7 {
+		return c.SHA[:7]
+	}
+	return c.SHA
+}
+
+// GenerateChangelogSection renders a Keep a Changelog section for a release, with
+// its commits grouped by Conventional Commits type and linked to their pull
+// requests and commits
+func GenerateChangelogSection(release ChangelogRelease, commits []Commit) string {
+	var section strings.Builder
+	heading := "[" + release.Version + "]"
+	if release.RepositoryURL != "" {
+		if release.PreviousTag != "" {
+			heading += fmt.Sprintf("(%s/compare/%s...%s)", release.RepositoryURL, release.PreviousTag, release.Tag)
+		} else {
+			heading += fmt.Sprintf("(%s/releases/tag/%s)", release.RepositoryURL, release.Tag)
+		}
+	}
+	fmt.Fprintf(&section, "## %s - %s\n", heading, release.Date)
+
+	groups := GroupChangelogEntries(commits)
+	for _, group := range groups {
+		fmt.Fprintf(&section, "\n### %s\n\n", group.Heading)
+		for _, entry := range group.Entries {
+			section.WriteString(formatChangelogEntry(entry, release.RepositoryURL) + "\n")
+		}
+	}
+
+	if len(groups) == 0 {
+		section.WriteString("\nNo notable changes.\n")
+	}
+
+	return section.String()
+}
+
+// formatChangelogEntry renders a changelog entry as a list item linked to its pull
+// request and commit
+func formatChangelogEntry(entry ChangelogEntry, repositoryURL string) string {
+	item := "- "
+	if entry.Scope != "" {
+		item += "**" + entry.Scope + ":** "
+	}
+	item += entry.Description
+
+	shortSHA := entry.Commit.ShortSHA()
+	if repositoryURL != "" {
+		if entry.PullRequest != 0 {
+			item += fmt.Sprintf(" ([#%d](%s/pull/%d))", entry.PullRequest, repositoryURL, entry.PullRequest)
+		}
+		item += fmt.Sprintf(" ([%s](%s/commit/%s))", shortSHA, repositoryURL, entry.Commit.SHA)
+	} else {
+		if entry.PullRequest != 0 {
+			item += fmt.Sprintf(" (#%d)", entry.PullRequest)
+		}
+		item += " (" + shortSHA + ")"
+	}
+
+	return item
+}
+
+// InsertChangelogSection inserts a release section into a Keep a Changelog file,
+// below its header and any Unreleased section and above the previous releases. An
+// empty changelog gets the standard header. It fails if the version already has a
+// section.
+func InsertChangelogSection(changelog, section, version string) (string, error) {
+	if strings.TrimSpace(changelog) == "" {
+		changelog = changelogHeader
+	}
+
+	lines := strings.SplitAfter(changelog, "\n")
+	insertAt := len(lines)
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+
+		heading := strings.TrimSpace(strings.TrimPrefix(line, "## "))
+		if strings.HasPrefix(heading, "["+version+"]") || strings.HasPrefix(heading, version+" ") || heading == version {
+			return "", fmt.Errorf("changelog already has a section for %s", version)
+		}
+		if insertAt == len(lines) && !strings.HasPrefix(strings.ToLower(heading), "[unreleased]") {
+			insertAt = i
+		}
+	}
+
+	before := strings.Join(lines[:insertAt], "")
+	after := strings.Join(lines[insertAt:], "")
+
+	// Keep exactly one blank line around the new section
+	before = strings.TrimRight(before, "\n") + "\n\n"
+	if after != "" {
+		section = strings.TrimRight(section, "\n") + "\n\n"
+	}
+
+	return before + section + after, nil
+}
@@ -1,6 +1,12 @@
 package semveractions
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/half-ogre/go-kit/versionkit"
@@ -200,15 +206,13 @@ func TestFormatVersionWithPrefix(t *testing.T) {
 
 func TestIncrementVersion(t *testing.T) {
 	tests := []struct {
-		name           string
-		current        *versionkit.SemanticVersion
-		incrementMajor bool
-		incrementMinor bool
-		expectedMajor  uint
-		expectedMinor  uint
-		expectedPatch  uint
-		expectedType   string
-		expectError    bool
+		name          string
+		current       *versionkit.SemanticVersion
+		increment     string
+		expectedMajor uint
+		expectedMinor uint
+		expectedPatch uint
+		expectError   bool
 	}{
 		{
 			name: "patch increment",
@@ -217,13 +221,11 @@ func TestIncrementVersion(t *testing.T) {
 				MinorVersion: 2,
 				PatchVersion: 3,
 			},
-			incrementMajor: false,
-			incrementMinor: false,
-			expectedMajor:  1,
-			expectedMinor:  2,
-			expectedPatch:  4,
-			expectedType:   "patch",
-			expectError:    false,
+			increment:     "patch",
+			expectedMajor: 1,
+			expectedMinor: 2,
+			expectedPatch: 4,
+			expectError:   false,
 		},
 		{
 			name: "minor increment",
@@ -232,13 +234,11 @@ func TestIncrementVersion(t *testing.T) {
 				MinorVersion: 2,
 				PatchVersion: 3,
 			},
-			incrementMajor: false,
-			incrementMinor: true,
-			expectedMajor:  1,
-			expectedMinor:  3,
-			expectedPatch:  0,
-			expectedType:   "minor",
-			expectError:    false,
+			increment:     "minor",
+			expectedMajor: 1,
+			expectedMinor: 3,
+			expectedPatch: 0,
+			expectError:   false,
 		},
 		{
 			name: "major increment",
@@ -247,16 +247,28 @@ func TestIncrementVersion(t *testing.T) {
 				MinorVersion: 2,
 				PatchVersion: 3,
 			},
-			incrementMajor: true,
-			incrementMinor: false,
-			expectedMajor:  2,
-			expectedMinor:  0,
-			expectedPatch:  0,
-			expectedType:   "major",
-			expectError:    false,
+			increment:     "major",
+			expectedMajor: 2,
+			expectedMinor: 0,
+			expectedPatch: 0,
+			expectError:   false,
 		},
 		{
-			name: "removes prerelease and build metadata",
+			name: "removes build metadata",
+			current: &versionkit.SemanticVersion{
+				MajorVersion:  1,
+				MinorVersion:  2,
+				PatchVersion:  3,
+				BuildMetadata: "build.456",
+			},
+			increment:     "patch",
+			expectedMajor: 1,
+			expectedMinor: 2,
+			expectedPatch: 4,
+			expectError:   false,
+		},
+		{
+			name: "patch increment promotes prerelease",
 			current: &versionkit.SemanticVersion{
 				MajorVersion:      1,
 				MinorVersion:      2,
@@ -264,30 +276,107 @@ func TestIncrementVersion(t *testing.T) {
 				PreReleaseVersion: "alpha.1",
 				BuildMetadata:     "build.456",
 			},
-			incrementMajor: false,
-			incrementMinor: false,
-			expectedMajor:  1,
-			expectedMinor:  2,
-			expectedPatch:  4,
-			expectedType:   "patch",
-			expectError:    false,
+			increment:     "patch",
+			expectedMajor: 1,
+			expectedMinor: 2,
+			expectedPatch: 3,
+			expectError:   false,
+		},
+		{
+			name: "minor increment promotes minor prerelease",
+			current: &versionkit.SemanticVersion{
+				MajorVersion:      1,
+				MinorVersion:      3,
+				PatchVersion:      0,
+				PreReleaseVersion: "rc.2",
+			},
+			increment:     "minor",
+			expectedMajor: 1,
+			expectedMinor: 3,
+			expectedPatch: 0,
+			expectError:   false,
+		},
+		{
+			name: "minor increment past patch prerelease",
+			current: &versionkit.SemanticVersion{
+				MajorVersion:      1,
+				MinorVersion:      3,
+				PatchVersion:      1,
+				PreReleaseVersion: "rc.0",
+			},
+			increment:     "minor",
+			expectedMajor: 1,
+			expectedMinor: 4,
+			expectedPatch: 0,
+			expectError:   false,
+		},
+		{
+			name: "major increment promotes major prerelease",
+			current: &versionkit.SemanticVersion{
+				MajorVersion:      2,
+				MinorVersion:      0,
+				PatchVersion:      0,
+				PreReleaseVersion: "beta.3",
+			},
+			increment:     "major",
+			expectedMajor: 2,
+			expectedMinor: 0,
+			expectedPatch: 0,
+			expectError:   false,
+		},
+		{
+			name: "major increment past minor prerelease",
+			current: &versionkit.SemanticVersion{
+				MajorVersion:      1,
+				MinorVersion:      3,
+				PatchVersion:      0,
+				PreReleaseVersion: "rc.2",
+			},
+			increment:     "major",
+			expectedMajor: 2,
+			expectedMinor: 0,
+			expectedPatch: 0,
+			expectError:   false,
 		},
 		{
-			name: "both major and minor - should error",
+			name: "none keeps the current version",
+			current: &versionkit.SemanticVersion{
+				MajorVersion:  1,
+				MinorVersion:  2,
+				PatchVersion:  3,
+				BuildMetadata: "build.456",
+			},
+			increment:     "none",
+			expectedMajor: 1,
+			expectedMinor: 2,
+			expectedPatch: 3,
+			expectError:   false,
+		},
+		{
+			name: "auto must be resolved first",
 			current: &versionkit.SemanticVersion{
 				MajorVersion: 1,
 				MinorVersion: 2,
 				PatchVersion: 3,
 			},
-			incrementMajor: true,
-			incrementMinor: true,
-			expectError:    true,
+			increment:   "auto",
+			expectError: true,
+		},
+		{
+			name: "unknown increment - should error",
+			current: &versionkit.SemanticVersion{
+				MajorVersion: 1,
+				MinorVersion: 2,
+				PatchVersion: 3,
+			},
+			increment:   "huge",
+			expectError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			newVersion, incrementType, err := IncrementVersion(tt.current, tt.incrementMajor, tt.incrementMinor)
+			newVersion, err := IncrementVersion(tt.current, tt.increment, "")
 
 			if tt.expectError {
 				if err == nil {
@@ -310,9 +399,6 @@ func TestIncrementVersion(t *testing.T) {
 			if newVersion.PatchVersion != tt.expectedPatch {
 				t.Errorf("Patch version = %d, want %d", newVersion.PatchVersion, tt.expectedPatch)
 			}
-			if incrementType != tt.expectedType {
-				t.Errorf("Increment type = %q, want %q", incrementType, tt.expectedType)
-			}
 
 			// Verify prerelease and build metadata are removed
 			if newVersion.PreReleaseVersion != "" {
@@ -325,6 +411,154 @@ func TestIncrementVersion(t *testing.T) {
 	}
 }
 
+func TestLegacyIncrement(t *testing.T) {
+	tests := []struct {
+		name           string
+		incrementMajor bool
+		incrementMinor bool
+		expected       string
+		errorMsg       string
+	}{
+		{name: "nothing set", expected: ""},
+		{name: "major", incrementMajor: true, expected: "major"},
+		{name: "minor", incrementMinor: true, expected: "minor"},
+		{name: "major and minor", incrementMajor: true, incrementMinor: true, errorMsg: "cannot increment both major and minor versions simultaneously"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LegacyIncrement(tt.incrementMajor, tt.incrementMinor)
+
+			if tt.errorMsg != "" {
+				if err == nil {
+					t.Error("Expected error but got none")
+				} else if err.Error() != tt.errorMsg {
+					t.Errorf("Expected error message %q, got %q", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if result != tt.expected {
+				t.Errorf("LegacyIncrement() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateIncrement(t *testing.T) {
+	for _, increment := range IncrementTypes {
+		if err := ValidateIncrement(increment); err != nil {
+			t.Errorf("ValidateIncrement(%q) returned error: %v", increment, err)
+		}
+	}
+
+	err := ValidateIncrement("bump")
+	expected := `increment must be one of major, minor, patch, premajor, preminor, prepatch, prerelease, release, none, auto, got "bump"`
+	if err == nil || err.Error() != expected {
+		t.Errorf("ValidateIncrement(\"bump\") error = %v, want %q", err, expected)
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     string
+		expected    string
+		expectError bool
+	}{
+		{name: "release candidate", current: "1.3.0-rc.2", expected: "1.3.0"},
+		{name: "drops build metadata", current: "2.0.0-beta.1+build.9", expected: "2.0.0"},
+		{name: "release version", current: "1.2.3", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := versionkit.ParseSemanticVersion(tt.current)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", tt.current, err)
+			}
+
+			newVersion, err := ReleaseVersion(current)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if newVersion.String() != tt.expected {
+				t.Errorf("ReleaseVersion(%q) = %q, want %q", tt.current, newVersion.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestIncrementPrerelease(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       string
+		incrementType string
+		preid         string
+		expected      string
+		expectError   bool
+	}{
+		{name: "premajor with preid", current: "1.2.3", incrementType: "premajor", preid: "rc", expected: "2.0.0-rc.0"},
+		{name: "preminor with preid", current: "1.2.3", incrementType: "preminor", preid: "beta", expected: "1.3.0-beta.0"},
+		{name: "prepatch with preid", current: "1.2.3", incrementType: "prepatch", preid: "alpha", expected: "1.2.4-alpha.0"},
+		{name: "prepatch without preid", current: "1.2.3", incrementType: "prepatch", expected: "1.2.4-0"},
+		{name: "preminor from a prerelease", current: "1.3.0-rc.2", incrementType: "preminor", preid: "rc", expected: "1.4.0-rc.0"},
+		{name: "prerelease from a release", current: "1.2.3", incrementType: "prerelease", preid: "rc", expected: "1.2.4-rc.0"},
+		{name: "prerelease increments number", current: "1.3.0-rc.1", incrementType: "prerelease", preid: "rc", expected: "1.3.0-rc.2"},
+		{name: "prerelease increments numerically", current: "1.3.0-rc.9", incrementType: "prerelease", preid: "rc", expected: "1.3.0-rc.10"},
+		{name: "prerelease without preid keeps identifier", current: "1.3.0-beta.4", incrementType: "prerelease", expected: "1.3.0-beta.5"},
+		{name: "prerelease with new preid restarts", current: "1.3.0-beta.4", incrementType: "prerelease", preid: "rc", expected: "1.3.0-rc.0"},
+		{name: "prerelease without number gains one", current: "1.3.0-rc", incrementType: "prerelease", preid: "rc", expected: "1.3.0-rc.0"},
+		{name: "prerelease increments last numeric identifier", current: "1.3.0-rc.1.ios", incrementType: "prerelease", expected: "1.3.0-rc.2.ios"},
+		{name: "prerelease drops build metadata", current: "1.3.0-rc.1+build.7", incrementType: "prerelease", expected: "1.3.0-rc.2"},
+		{name: "invalid increment type", current: "1.2.3", incrementType: "minor", expectError: true},
+		{name: "invalid preid", current: "1.2.3", incrementType: "prepatch", preid: "rc_1", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := versionkit.ParseSemanticVersion(tt.current)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", tt.current, err)
+			}
+
+			newVersion, err := IncrementPrerelease(current, tt.incrementType, tt.preid)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if newVersion.String() != tt.expected {
+				t.Errorf("IncrementPrerelease(%q, %q, %q) = %q, want %q",
+					tt.current, tt.incrementType, tt.preid, newVersion.String(), tt.expected)
+			}
+		})
+	}
+}
+
 func TestFilterTagsByPrefix(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -374,4 +608,610 @@ func TestFilterTagsByPrefix(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name     string
+		subject  string
+		body     string
+		expected ConventionalCommit
+		ok       bool
+	}{
+		{
+			name:     "feature",
+			subject:  "feat: add paging",
+			expected: ConventionalCommit{Type: "feat", Description: "add paging"},
+			ok:       true,
+		},
+		{
+			name:     "scoped fix",
+			subject:  "fix(api): handle empty response",
+			expected: ConventionalCommit{Type: "fix", Scope: "api", Description: "handle empty response"},
+			ok:       true,
+		},
+		{
+			name:     "breaking marker",
+			subject:  "refactor(core)!: drop legacy inputs",
+			expected: ConventionalCommit{Type: "refactor", Scope: "core", Breaking: true, Description: "drop legacy inputs"},
+			ok:       true,
+		},
+		{
+			name:     "breaking change footer",
+			subject:  "feat: rename outputs",
+			body:     "Outputs now use kebab case.\n\nBREAKING CHANGE: version_core is now version-core",
+			expected: ConventionalCommit{Type: "feat", Breaking: true, Description: "rename outputs"},
+			ok:       true,
+		},
+		{
+			name:     "breaking-change footer token",
+			subject:  "fix: tighten validation",
+			body:     "BREAKING-CHANGE: invalid prefixes now fail",
+			expected: ConventionalCommit{Type: "fix", Breaking: true, Description: "tighten validation"},
+			ok:       true,
+		},
+		{
+			name:     "uppercase type",
+			subject:  "Feat: add paging",
+			expected: ConventionalCommit{Type: "feat", Description: "add paging"},
+			ok:       true,
+		},
+		{
+			name:    "not conventional",
+			subject: "Add paging",
+			ok:      false,
+		},
+		{
+			name:    "missing space after colon",
+			subject: "feat:add paging",
+			ok:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conventional, ok := ParseConventionalCommit(tt.subject, tt.body)
+
+			if ok != tt.ok {
+				t.Fatalf("ParseConventionalCommit(%q) ok = %v, want %v", tt.subject, ok, tt.ok)
+			}
+			if conventional != tt.expected {
+				t.Errorf("ParseConventionalCommit(%q) = %+v, want %+v", tt.subject, conventional, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAutoIncrement(t *testing.T) {
+	tests := []struct {
+		name           string
+		current        string
+		commits        []Commit
+		expected       string
+		expectedCauses []string
+	}{
+		{
+			name:    "fix is a patch",
+			current: "1.2.3",
+			commits: []Commit{
+				{SHA: "a1", Subject: "chore: update deps"},
+				{SHA: "b2", Subject: "fix: handle empty tags"},
+			},
+			expected:       "patch",
+			expectedCauses: []string{"b2"},
+		},
+		{
+			name:    "perf is a patch",
+			current: "1.2.3",
+			commits: []Commit{
+				{SHA: "a1", Subject: "perf: cache tag list"},
+			},
+			expected:       "patch",
+			expectedCauses: []string{"a1"},
+		},
+		{
+			name:    "feat outranks fixes",
+			current: "1.2.3",
+			commits: []Commit{
+				{SHA: "a1", Subject: "fix: handle empty tags"},
+				{SHA: "b2", Subject: "feat: add prefix input"},
+				{SHA: "c3", Subject: "feat(api): add paging"},
+			},
+			expected:       "minor",
+			expectedCauses: []string{"b2", "c3"},
+		},
+		{
+			name:    "breaking marker is a major",
+			current: "1.2.3",
+			commits: []Commit{
+				{SHA: "a1", Subject: "feat: add prefix input"},
+				{SHA: "b2", Subject: "feat!: remove increment-major"},
+			},
+			expected:       "major",
+			expectedCauses: []string{"b2"},
+		},
+		{
+			name:    "breaking footer is a major",
+			current: "2.0.0",
+			commits: []Commit{
+				{SHA: "a1", Subject: "fix: tighten validation", Body: "BREAKING CHANGE: invalid prefixes now fail"},
+			},
+			expected:       "major",
+			expectedCauses: []string{"a1"},
+		},
+		{
+			name:    "breaking change before 1.0.0 is a minor",
+			current: "0.4.1",
+			commits: []Commit{
+				{SHA: "a1", Subject: "feat: add prefix input"},
+				{SHA: "b2", Subject: "refactor!: rename outputs"},
+			},
+			expected:       "minor",
+			expectedCauses: []string{"a1", "b2"},
+		},
+		{
+			name:    "no releasable commits",
+			current: "1.2.3",
+			commits: []Commit{
+				{SHA: "a1", Subject: "docs: update README"},
+				{SHA: "b2", Subject: "Merge branch 'main'"},
+			},
+			expected: "none",
+		},
+		{
+			name:     "no commits",
+			current:  "1.2.3",
+			expected: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := versionkit.ParseSemanticVersion(tt.current)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", tt.current, err)
+			}
+
+			increment, causes := AutoIncrement(tt.commits, current)
+
+			if increment != tt.expected {
+				t.Errorf("AutoIncrement() increment = %q, want %q", increment, tt.expected)
+			}
+
+			var causeSHAs []string
+			for _, cause := range causes {
+				causeSHAs = append(causeSHAs, cause.SHA)
+			}
+			if strings.Join(causeSHAs, ",") != strings.Join(tt.expectedCauses, ",") {
+				t.Errorf("AutoIncrement() causes = %v, want %v", causeSHAs, tt.expectedCauses)
+			}
+		})
+	}
+}
+
+func TestRenderBuildMetadata(t *testing.T) {
+	data := BuildMetadata{
+		SHA:        "abc1234def5678abc1234def5678abc1234def56",
+		ShortSHA:   "abc1234",
+		RunNumber:  "42",
+		RunID:      "9876543210",
+		RunAttempt: "2",
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		data        BuildMetadata
+		expected    string
+		expectError string
+	}{
+		{
+			name:     "short sha and run number",
+			template: "sha.{{.ShortSHA}}.run.{{.RunNumber}}",
+			data:     data,
+			expected: "sha.abc1234.run.42",
+		},
+		{
+			name:     "static metadata",
+			template: "build.5",
+			data:     data,
+			expected: "build.5",
+		},
+		{
+			name:     "run id and attempt",
+			template: "{{.RunID}}-{{.RunAttempt}}",
+			data:     data,
+			expected: "9876543210-2",
+		},
+		{
+			name:        "unknown field",
+			template:    "{{.Branch}}",
+			data:        data,
+			expectError: `invalid build-metadata template: template: build-metadata:1:2: executing "build-metadata" at <.Branch>: can't evaluate field Branch in type semveractions.BuildMetadata`,
+		},
+		{
+			name:        "unclosed action",
+			template:    "sha.{{.ShortSHA",
+			data:        data,
+			expectError: `invalid build-metadata template: template: build-metadata:1: unclosed action`,
+		},
+		{
+			name:        "invalid characters",
+			template:    "sha_{{.ShortSHA}}",
+			data:        data,
+			expectError: `build metadata must be dot-separated alphanumeric identifiers, got "sha_abc1234"`,
+		},
+		{
+			name:        "empty identifier outside of a run",
+			template:    "sha.{{.ShortSHA}}.run.{{.RunNumber}}",
+			data:        BuildMetadata{SHA: data.SHA, ShortSHA: data.ShortSHA},
+			expectError: `build metadata must be dot-separated alphanumeric identifiers, got "sha.abc1234.run."`,
+		},
+		{
+			name:        "empty template",
+			template:    "",
+			data:        data,
+			expectError: `build metadata must be dot-separated alphanumeric identifiers, got ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, err := RenderBuildMetadata(tt.template, tt.data)
+
+			if tt.expectError != "" {
+				if err == nil {
+					t.Errorf("Expected error %q but got none", tt.expectError)
+				} else if err.Error() != tt.expectError {
+					t.Errorf("Expected error %q, got %q", tt.expectError, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if metadata != tt.expected {
+				t.Errorf("RenderBuildMetadata(%q) = %q, want %q", tt.template, metadata, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateBuildMetadataTemplate(t *testing.T) {
+	if err := ValidateBuildMetadataTemplate("sha.{{.ShortSHA}}.run.{{.RunNumber}}"); err != nil {
+		t.Errorf("ValidateBuildMetadataTemplate() returned error: %v", err)
+	}
+	if err := ValidateBuildMetadataTemplate("{{.Branch}}"); err == nil {
+		t.Error("Expected error for unknown field but got none")
+	}
+	if err := ValidateBuildMetadataTemplate("sha+{{.ShortSHA}}"); err == nil {
+		t.Error("Expected error for invalid characters but got none")
+	}
+}
+
+func TestGenerateChangelogSection(t *testing.T) {
+	commits := []Commit{
+		{SHA: "1111111aaaaaaa", Subject: "feat(api): add paging (#12)"},
+		{SHA: "2222222bbbbbbb", Subject: "fix: handle empty tags"},
+		{SHA: "3333333ccccccc", Subject: "chore: bump deps"},
+		{SHA: "4444444ddddddd", Subject: "refactor!: rename outputs"},
+		{SHA: "5555555eeeeeee", Subject: "Update README"},
+	}
+
+	tests := []struct {
+		name     string
+		release  ChangelogRelease
+		commits  []Commit
+		expected string
+	}{
+		{
+			name: "grouped and linked",
+			release: ChangelogRelease{
+				Version:       "1.3.0",
+				Tag:           "v1.3.0",
+				PreviousTag:   "v1.2.0",
+				Date:          "2026-10-18",
+				RepositoryURL: "https://github.com/owner/repo",
+			},
+			commits: commits,
+			expected: `## [1.3.0](https://github.com/owner/repo/compare/v1.2.0...v1.3.0) - 2026-10-18
+
+### Breaking Changes
+
+- rename outputs ([4444444](https://github.com/owner/repo/commit/4444444ddddddd))
+
+### Features
+
+- **api:** add paging ([#12](https://github.com/owner/repo/pull/12)) ([1111111](https://github.com/owner/repo/commit/1111111aaaaaaa))
+
+### Bug Fixes
+
+- handle empty tags ([2222222](https://github.com/owner/repo/commit/2222222bbbbbbb))
+
+### Other Changes
+
+- Update README ([5555555](https://github.com/owner/repo/commit/5555555eeeeeee))
+`,
+		},
+		{
+			name: "first release without a repository",
+			release: ChangelogRelease{
+				Version: "0.1.0",
+				Tag:     "v0.1.0",
+				Date:    "2026-10-18",
+			},
+			commits: commits[:2],
+			expected: `## [0.1.0] - 2026-10-18
+
+### Features
+
+- **api:** add paging (#12) (1111111)
+
+### Bug Fixes
+
+- handle empty tags (2222222)
+`,
+		},
+		{
+			name: "first release links to the tag",
+			release: ChangelogRelease{
+				Version:       "0.1.0",
+				Tag:           "v0.1.0",
+				Date:          "2026-10-18",
+				RepositoryURL: "https://github.com/owner/repo",
+			},
+			commits: nil,
+			expected: `## [0.1.0](https://github.com/owner/repo/releases/tag/v0.1.0) - 2026-10-18
+
+No notable changes.
+`,
+		},
+		{
+			name: "only hidden commits",
+			release: ChangelogRelease{
+				Version: "1.2.1",
+				Tag:     "v1.2.1",
+				Date:    "2026-10-18",
+			},
+			commits: commits[2:3],
+			expected: `## [1.2.1] - 2026-10-18
+
+No notable changes.
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := GenerateChangelogSection(tt.release, tt.commits)
+			if section != tt.expected {
+				t.Errorf("GenerateChangelogSection() =\n%s\nwant\n%s", section, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInsertChangelogSection(t *testing.T) {
+	section := "## [1.3.0] - 2026-10-18\n\n### Features\n\n- add paging (1111111)\n"
+
+	tests := []struct {
+		name        string
+		changelog   string
+		version     string
+		expected    string
+		expectError string
+	}{
+		{
+			name:      "new changelog gets the header",
+			changelog: "",
+			version:   "1.3.0",
+			expected:  changelogHeader + "\n" + section,
+		},
+		{
+			name:      "above the previous release",
+			changelog: "# Changelog\n\n## [1.2.0] - 2026-09-01\n\n- old change\n",
+			version:   "1.3.0",
+			expected:  "# Changelog\n\n" + section + "\n## [1.2.0] - 2026-09-01\n\n- old change\n",
+		},
+		{
+			name:      "below the unreleased section",
+			changelog: "# Changelog\n\n## [Unreleased]\n\n- pending\n\n## [1.2.0] - 2026-09-01\n",
+			version:   "1.3.0",
+			expected:  "# Changelog\n\n## [Unreleased]\n\n- pending\n\n" + section + "\n## [1.2.0] - 2026-09-01\n",
+		},
+		{
+			name:      "after a header without releases",
+			changelog: "# Changelog\n",
+			version:   "1.3.0",
+			expected:  "# Changelog\n\n" + section,
+		},
+		{
+			name:        "version already has a section",
+			changelog:   "# Changelog\n\n## [1.3.0](https://example.com) - 2026-10-01\n",
+			version:     "1.3.0",
+			expectError: "changelog already has a section for 1.3.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changelog, err := InsertChangelogSection(tt.changelog, section, tt.version)
+
+			if tt.expectError != "" {
+				if err == nil {
+					t.Errorf("Expected error %q but got none", tt.expectError)
+				} else if err.Error() != tt.expectError {
+					t.Errorf("Expected error %q, got %q", tt.expectError, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if changelog != tt.expected {
+				t.Errorf("InsertChangelogSection() =\n%q\nwant\n%q", changelog, tt.expected)
+			}
+		})
+	}
+}
+
+// initTestRepo creates a git repository in a temporary working directory with the
+// given number of empty commits, tagging the commit at each index in tags, and
+// returns the commit SHAs in order
+func initTestRepo(t *testing.T, commits int, tags map[int]string) []string {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	git := func(args ...string) string {
+		output, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	git("init")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test User")
+	var shas []string
+	for i := 0; i < commits; i++ {
+		git("commit", "--allow-empty", "-m", fmt.Sprintf("fix: change %d", i))
+		shas = append(shas, git("rev-parse", "HEAD"))
+		if tag, ok := tags[i]; ok {
+			git("tag", tag)
+		}
+	}
+	return shas
+}
+
+func TestGetMergedTags(t *testing.T) {
+	shas := initTestRepo(t, 3, map[int]string{0: "v1.0.0", 2: "v1.1.0"})
+
+	tests := []struct {
+		name     string
+		commit   string
+		expected []string
+	}{
+		{"commit on the newest tag", shas[2], []string{"v1.0.0", "v1.1.0"}},
+		{"commit behind the newest tag", shas[1], []string{"v1.0.0"}},
+		{"commit on the oldest tag", shas[0], []string{"v1.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := GetMergedTags(tt.commit)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tags, tt.expected) {
+				t.Errorf("Expected tags %v, got %v", tt.expected, tags)
+			}
+		})
+	}
+
+	if _, err := GetMergedTags("does-not-exist"); err == nil {
+		t.Error("Expected error for an unknown commit, got nil")
+	}
+}
+
+func TestCountCommitsSince(t *testing.T) {
+	shas := initTestRepo(t, 4, map[int]string{1: "v1.0.0"})
+
+	tests := []struct {
+		name     string
+		since    string
+		commit   string
+		expected int
+	}{
+		{"whole history without a tag", "", shas[3], 4},
+		{"commits after the tag", "v1.0.0", shas[3], 2},
+		{"commit on the tag", "v1.0.0", shas[1], 0},
+		{"commit behind the tag", "v1.0.0", shas[0], 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := CountCommitsSince(tt.since, tt.commit)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected %d commits, got %d", tt.expected, count)
+			}
+		})
+	}
+
+	if _, err := CountCommitsSince("v9.9.9", shas[3]); err == nil {
+		t.Error("Expected error for an unknown tag, got nil")
+	}
+}
+
+func TestGetCommitsSince(t *testing.T) {
+	shas := initTestRepo(t, 2, map[int]string{0: "v1.0.0"})
+
+	git := func(args ...string) string {
+		output, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	// Merge a feature branch so the range holds a merge commit
+	git("checkout", "-q", "-b", "feature", shas[0])
+	git("commit", "--allow-empty", "-m", "feat: add paging")
+	feature := git("rev-parse", "HEAD")
+	git("checkout", "-q", "-")
+	git("merge", "--no-ff", "-m", "Merge pull request #7 from test/feature", "feature")
+
+	commits, err := GetCommitsSince("v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Commits made in the same second have no stable order, so compare as a set
+	found := make(map[string]bool)
+	for _, commit := range commits {
+		found[commit.SHA] = true
+	}
+	expected := map[string]bool{shas[1]: true, feature: true}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected commits %v, got %v", expected, found)
+	}
+
+	if _, err := GetCommitsSince("v9.9.9", "HEAD"); err == nil {
+		t.Error("Expected error for an unknown tag, got nil")
+	}
+}
+
+func TestSetMultilineOutput(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output")
+	if err := os.WriteFile(outputFile, nil, 0644); err != nil {
+		t.Fatalf("Failed to create output file: %v", err)
+	}
+
+	os.Setenv("GITHUB_OUTPUT", outputFile)
+	defer os.Unsetenv("GITHUB_OUTPUT")
+
+	if err := SetMultilineOutput("changelog", "line one\nline two"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(outputFile)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines in output file, got %d: %q", len(lines), content)
+	}
+
+	delimiter := strings.TrimPrefix(lines[0], "changelog<<")
+	if delimiter == lines[0] || delimiter == "" {
+		t.Errorf("Expected heredoc header, got %q", lines[0])
+	}
+	if lines[1] != "line one" || lines[2] != "line two" {
+		t.Errorf("Unexpected body lines: %q", lines[1:3])
+	}
+	if lines[3] != delimiter {
+		t.Errorf("Expected closing delimiter %q, got %q", delimiter, lines[3])
+	}
+}
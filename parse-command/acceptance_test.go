@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAcceptanceParseCommandAuthorized(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && strings.Contains(r.URL.Path, "/issues/comments/42/reactions") {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+		} else if r.Method == "GET" && strings.Contains(r.URL.Path, "/collaborators/octocat/permission") {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"permission": "write", "role_name": "write"}`)
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"GITHUB_EVENT_PATH":  writeEvent(t, "/deploy staging force=true"),
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	expectedStdout := []string{
+		"Parsed command /deploy",
+		"::set-output name=command::deploy",
+		`::set-output name=args-json::{"args":["staging"],"options":{"force":"true"}}`,
+		"::set-output name=authorized::true",
+	}
+	for _, expected := range expectedStdout {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected stdout to contain %q, got: %s", expected, stdout)
+		}
+	}
+}
+
+func TestAcceptanceParseCommandUnauthorized(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server where the commenter only has read access
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/reactions") {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"permission": "read", "role_name": "read"}`)
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"GITHUB_EVENT_PATH":  writeEvent(t, "/deploy staging"),
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stdout, "::set-output name=authorized::false") {
+		t.Errorf("Expected stdout to contain authorized=false, got: %s", stdout)
+	}
+}
+
+func TestAcceptanceParseCommandMissingEvent(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup environment with an event path that does not exist
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"GITHUB_EVENT_PATH":  filepath.Join(t.TempDir(), "missing.json"),
+		"INPUT_GITHUB_TOKEN": "test-token",
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stderr, "error reading event") {
+		t.Errorf("Expected stderr to contain 'error reading event', got: %s", stderr)
+	}
+}
+
+// setupEnv sets environment variables and returns the old values for restoration
+func setupEnv(envVars map[string]string) map[string]string {
+	oldEnv := make(map[string]string)
+	for key, value := range envVars {
+		oldEnv[key] = os.Getenv(key)
+		os.Setenv(key, value)
+	}
+	return oldEnv
+}
+
+// restoreEnv restores environment variables to their previous values
+func restoreEnv(oldEnv map[string]string) {
+	for key, value := range oldEnv {
+		if value == "" {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, value)
+		}
+	}
+}
+
+// buildBinary builds the parse-command binary and returns its path
+func buildBinary(t *testing.T) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	binaryPath := filepath.Join(tempDir, "parse-command")
+
+	cmd := exec.Command("go", "build", "-o", binaryPath, "main.go")
+	cmd.Dir = "." // Current directory should be parse-command/
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Failed to build binary: %v\nOutput: %s", err, output)
+	}
+
+	return binaryPath
+}
+
+// runCommand executes a command and returns stdout, stderr, and exit code
+func runCommand(cmd *exec.Cmd) (stdout, stderr string, exitCode int) {
+	stdoutBytes, stderrBytes, err := runCommandBytes(cmd)
+	stdout = string(stdoutBytes)
+	stderr = string(stderrBytes)
+
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			exitCode = exitError.ExitCode()
+		} else {
+			exitCode = -1 // Some other error
+		}
+	} else {
+		exitCode = 0
+	}
+
+	return stdout, stderr, exitCode
+}
+
+// runCommandBytes executes a command and returns stdout and stderr as bytes
+func runCommandBytes(cmd *exec.Cmd) (stdout, stderr []byte, err error) {
+	stdoutBuf := &strings.Builder{}
+	stderrBuf := &strings.Builder{}
+
+	cmd.Stdout = stdoutBuf
+	cmd.Stderr = stderrBuf
+
+	err = cmd.Run()
+	stdout = []byte(stdoutBuf.String())
+	stderr = []byte(stderrBuf.String())
+
+	return stdout, stderr, err
+}
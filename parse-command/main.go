@@ -0,0 +1,406 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/half-ogre/go-kit/actionskit"
+)
+
+type ReactionRequest struct {
+	Content string `json:"content"`
+}
+
+type User struct {
+	Login string `json:"login"`
+}
+
+type Comment struct {
+	ID   int    `json:"id"`
+	Body string `json:"body"`
+	User User   `json:"user"`
+}
+
+type Issue struct {
+	Number int `json:"number"`
+}
+
+// IssueCommentEvent is the subset of the issue_comment event payload used by the action
+type IssueCommentEvent struct {
+	Action  string   `json:"action"`
+	Comment *Comment `json:"comment"`
+	Issue   Issue    `json:"issue"`
+}
+
+type CollaboratorPermission struct {
+	Permission string `json:"permission"`
+	RoleName   string `json:"role_name"`
+}
+
+// Command holds a parsed slash command
+type Command struct {
+	Name    string            `json:"-"`
+	Args    []string          `json:"args"`
+	Options map[string]string `json:"options"`
+}
+
+// Config holds the configuration for the parse-command action
+type Config struct {
+	Repository         string
+	EventPath          string
+	RequiredPermission string
+	Token              string
+}
+
+// Result holds the result of the parse-command action
+type Result struct {
+	Command    *Command
+	Authorized bool
+	Success    bool
+	Error      error
+}
+
+// permissionLevels ranks repository roles from least to most privileged
+var permissionLevels = map[string]int{
+	"none":     0,
+	"read":     1,
+	"triage":   2,
+	"write":    3,
+	"maintain": 4,
+	"admin":    5,
+}
+
+var commandNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+func main() {
+	config, err := getConfigFromEnvironment()
+	if err != nil {
+		actionskit.Error(err.Error())
+		os.Exit(1)
+	}
+
+	result := run(config)
+	if result.Error != nil {
+		actionskit.Error(result.Error.Error())
+		os.Exit(1)
+	}
+
+	command := ""
+	argsJSON := "{}"
+	if result.Command != nil {
+		command = result.Command.Name
+		data, err := json.Marshal(result.Command)
+		if err != nil {
+			actionskit.Error(fmt.Sprintf("Failed to encode args-json: %v", err))
+			os.Exit(1)
+		}
+		argsJSON = string(data)
+		if result.Authorized {
+			actionskit.Info(fmt.Sprintf("Parsed command /%s", command))
+		} else {
+			actionskit.Warning(fmt.Sprintf("Commenter is not authorized to run /%s", command))
+		}
+	} else {
+		actionskit.Info("No command found in comment")
+	}
+
+	// Set outputs for GitHub Actions
+	err = actionskit.SetOutput("command", command)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set command output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("args-json", argsJSON)
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set args-json output: %v", err))
+		os.Exit(1)
+	}
+
+	err = actionskit.SetOutput("authorized", fmt.Sprintf("%t", result.Authorized))
+	if err != nil {
+		actionskit.Error(fmt.Sprintf("Failed to set authorized output: %v", err))
+		os.Exit(1)
+	}
+}
+
+// getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
+func getConfigFromEnvironment() (*Config, error) {
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
+	}
+
+	eventPath := os.Getenv("GITHUB_EVENT_PATH")
+	if eventPath == "" {
+		return nil, fmt.Errorf("GITHUB_EVENT_PATH environment variable is required")
+	}
+
+	requiredPermission := strings.ToLower(actionskit.GetInput("required-permission"))
+	if requiredPermission == "" {
+		requiredPermission = "write"
+	}
+	if _, ok := permissionLevels[requiredPermission]; !ok || requiredPermission == "none" {
+		return nil, fmt.Errorf("required-permission must be one of read, triage, write, maintain or admin, got %q", requiredPermission)
+	}
+
+	token := actionskit.GetInput("github-token")
+	if token == "" {
+		return nil, fmt.Errorf("github-token input is required")
+	}
+
+	return &Config{
+		Repository:         repository,
+		EventPath:          eventPath,
+		RequiredPermission: requiredPermission,
+		Token:              token,
+	}, nil
+}
+
+// run executes the parse-command action with the given configuration
+func run(config *Config) *Result {
+	result := &Result{Success: false}
+
+	event, err := readEvent(config.EventPath)
+	if err != nil {
+		result.Error = fmt.Errorf("error reading event: %v", err)
+		return result
+	}
+
+	command := parseCommand(event.Comment.Body)
+	if command == nil {
+		result.Success = true
+		return result
+	}
+	result.Command = command
+
+	// Acknowledge the command before doing any work
+	if err := addReaction(config.Repository, event.Comment.ID, "eyes", config.Token); err != nil {
+		result.Error = fmt.Errorf("error adding reaction: %v", err)
+		return result
+	}
+
+	permission, err := getCollaboratorPermission(config.Repository, event.Comment.User.Login, config.Token)
+	if err != nil {
+		result.Error = fmt.Errorf("error checking permission for %s: %v", event.Comment.User.Login, err)
+		addReaction(config.Repository, event.Comment.ID, "-1", config.Token)
+		return result
+	}
+	result.Authorized = hasPermission(permission, config.RequiredPermission)
+
+	// The reactions API has no check mark or cross, so +1 and -1 stand in for them
+	outcome := "+1"
+	if !result.Authorized {
+		outcome = "-1"
+	}
+	if err := addReaction(config.Repository, event.Comment.ID, outcome, config.Token); err != nil {
+		result.Error = fmt.Errorf("error adding reaction: %v", err)
+		return result
+	}
+
+	result.Success = true
+	return result
+}
+
+// readEvent loads the issue_comment event payload from the given path
+func readEvent(path string) (*IssueCommentEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var event IssueCommentEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event payload: %v", err)
+	}
+
+	if event.Comment == nil {
+		return nil, fmt.Errorf("event payload has no comment; parse-command must run on issue_comment events")
+	}
+
+	return &event, nil
+}
+
+// parseCommand finds the first /command line in a comment body and splits it into
+// positional arguments and key=value options. It returns nil if no command is present.
+func parseCommand(body string) *Command {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "/") {
+			continue
+		}
+
+		tokens := splitArgs(line)
+		name := strings.TrimPrefix(tokens[0], "/")
+		if !commandNamePattern.MatchString(name) {
+			continue
+		}
+
+		command := &Command{
+			Name:    strings.ToLower(name),
+			Args:    []string{},
+			Options: map[string]string{},
+		}
+		for _, token := range tokens[1:] {
+			if key, value, ok := strings.Cut(token, "="); ok && key != "" {
+				command.Options[key] = value
+			} else {
+				command.Args = append(command.Args, token)
+			}
+		}
+
+		return command
+	}
+
+	return nil
+}
+
+// splitArgs splits a line on whitespace, keeping double-quoted text together
+func splitArgs(line string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	hasToken := false
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasToken = true
+		case unicode.IsSpace(r) && !inQuotes:
+			if hasToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				hasToken = false
+			}
+		default:
+			current.WriteRune(r)
+			hasToken = true
+		}
+	}
+
+	if hasToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens
+}
+
+// hasPermission reports whether the actual role meets the required role
+func hasPermission(actual, required string) bool {
+	return permissionLevels[strings.ToLower(actual)] >= permissionLevels[required]
+}
+
+func addReaction(repository string, commentID int, content, token string) error {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/issues/comments/%d/reactions", apiBase, repository, commentID)
+
+	// Create request body
+	request := ReactionRequest{
+		Content: content,
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body_bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Check status (200 means the reaction already existed)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body_bytes))
+	}
+
+	return nil
+}
+
+func getCollaboratorPermission(repository, username, token string) (string, error) {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/collaborators/%s/permission", apiBase, repository, username)
+
+	// Create request
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// Check status
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var permission CollaboratorPermission
+	if err := json.NewDecoder(resp.Body).Decode(&permission); err != nil {
+		return "", err
+	}
+
+	// role_name distinguishes triage and maintain, which permission folds into read and write
+	if permission.RoleName != "" {
+		if _, ok := permissionLevels[permission.RoleName]; ok {
+			return permission.RoleName, nil
+		}
+	}
+
+	return permission.Permission, nil
+}
@@ -0,0 +1,489 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGetConfigFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupEnv    func()
+		cleanupEnv  func()
+		expectError bool
+		errorMsg    string
+		expected    *Config
+	}{
+		{
+			name: "valid configuration with defaults",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("GITHUB_EVENT_PATH", "/tmp/event.json")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("GITHUB_EVENT_PATH")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:         "test/repo",
+				EventPath:          "/tmp/event.json",
+				RequiredPermission: "write",
+				Token:              "test-token",
+			},
+		},
+		{
+			name: "custom required permission",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("GITHUB_EVENT_PATH", "/tmp/event.json")
+				os.Setenv("INPUT_REQUIRED_PERMISSION", "Maintain")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("GITHUB_EVENT_PATH")
+				os.Unsetenv("INPUT_REQUIRED_PERMISSION")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:         "test/repo",
+				EventPath:          "/tmp/event.json",
+				RequiredPermission: "maintain",
+				Token:              "test-token",
+			},
+		},
+		{
+			name: "invalid required permission",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("GITHUB_EVENT_PATH", "/tmp/event.json")
+				os.Setenv("INPUT_REQUIRED_PERMISSION", "owner")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("GITHUB_EVENT_PATH")
+				os.Unsetenv("INPUT_REQUIRED_PERMISSION")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    `required-permission must be one of read, triage, write, maintain or admin, got "owner"`,
+		},
+		{
+			name: "missing event path",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    "GITHUB_EVENT_PATH environment variable is required",
+		},
+		{
+			name: "missing token",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("GITHUB_EVENT_PATH", "/tmp/event.json")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("GITHUB_EVENT_PATH")
+			},
+			expectError: true,
+			errorMsg:    "github-token input is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupEnv()
+			defer tt.cleanupEnv()
+
+			config, err := getConfigFromEnvironment()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				} else if err.Error() != tt.errorMsg {
+					t.Errorf("Expected error message %q, got %q", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if *config != *tt.expected {
+				t.Errorf("Config = %+v, want %+v", *config, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected *Command
+	}{
+		{
+			name: "command with args and options",
+			body: "/deploy staging env=prod force=true",
+			expected: &Command{
+				Name:    "deploy",
+				Args:    []string{"staging"},
+				Options: map[string]string{"env": "prod", "force": "true"},
+			},
+		},
+		{
+			name: "command on a later line",
+			body: "Looks good to me.\n\n  /approve  \nThanks!",
+			expected: &Command{
+				Name:    "approve",
+				Args:    []string{},
+				Options: map[string]string{},
+			},
+		},
+		{
+			name: "quoted values",
+			body: `/label "needs review" reason="waiting on QA"`,
+			expected: &Command{
+				Name:    "label",
+				Args:    []string{"needs review"},
+				Options: map[string]string{"reason": "waiting on QA"},
+			},
+		},
+		{
+			name: "command name is lowercased",
+			body: "/Deploy",
+			expected: &Command{
+				Name:    "deploy",
+				Args:    []string{},
+				Options: map[string]string{},
+			},
+		},
+		{
+			name: "leading equals is a positional arg",
+			body: "/calc =5",
+			expected: &Command{
+				Name:    "calc",
+				Args:    []string{"=5"},
+				Options: map[string]string{},
+			},
+		},
+		{
+			name:     "no command",
+			body:     "Just a regular comment",
+			expected: nil,
+		},
+		{
+			name:     "path is not a command",
+			body:     "See /usr/bin/env for details",
+			expected: nil,
+		},
+		{
+			name:     "slash alone is not a command",
+			body:     "/ deploy",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseCommand(tt.body)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseCommand(%q) = %+v, want %+v", tt.body, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		actual   string
+		required string
+		expected bool
+	}{
+		{actual: "admin", required: "write", expected: true},
+		{actual: "maintain", required: "write", expected: true},
+		{actual: "write", required: "write", expected: true},
+		{actual: "triage", required: "write", expected: false},
+		{actual: "read", required: "triage", expected: false},
+		{actual: "none", required: "read", expected: false},
+		{actual: "", required: "read", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.actual+"-"+tt.required, func(t *testing.T) {
+			result := hasPermission(tt.actual, tt.required)
+			if result != tt.expected {
+				t.Errorf("hasPermission(%q, %q) = %v, want %v", tt.actual, tt.required, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddReaction(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseCode int
+		expectError  bool
+	}{
+		{
+			name:         "reaction created",
+			responseCode: http.StatusCreated,
+			expectError:  false,
+		},
+		{
+			name:         "reaction already exists",
+			responseCode: http.StatusOK,
+			expectError:  false,
+		},
+		{
+			name:         "API error",
+			responseCode: http.StatusForbidden,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("Expected POST method, got %s", r.Method)
+				}
+				if r.URL.Path != "/repos/test/repo/issues/comments/42/reactions" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+
+				var request ReactionRequest
+				json.NewDecoder(r.Body).Decode(&request)
+				if request.Content != "eyes" {
+					t.Errorf("Expected content 'eyes', got %q", request.Content)
+				}
+
+				w.WriteHeader(tt.responseCode)
+				fmt.Fprint(w, `{"id": 1, "content": "eyes"}`)
+			}))
+			defer server.Close()
+
+			os.Setenv("GITHUB_API_URL", server.URL)
+			defer os.Unsetenv("GITHUB_API_URL")
+
+			err := addReaction("test/repo", 42, "eyes", "test-token")
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetCollaboratorPermission(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseCode int
+		responseBody string
+		expected     string
+		expectError  bool
+	}{
+		{
+			name:         "role name preferred",
+			responseCode: http.StatusOK,
+			responseBody: `{"permission": "write", "role_name": "maintain"}`,
+			expected:     "maintain",
+		},
+		{
+			name:         "falls back to permission",
+			responseCode: http.StatusOK,
+			responseBody: `{"permission": "read"}`,
+			expected:     "read",
+		},
+		{
+			name:         "custom role falls back to permission",
+			responseCode: http.StatusOK,
+			responseBody: `{"permission": "write", "role_name": "release-manager"}`,
+			expected:     "write",
+		},
+		{
+			name:         "API error",
+			responseCode: http.StatusNotFound,
+			responseBody: `{"message": "Not Found"}`,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/test/repo/collaborators/octocat/permission" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.responseCode)
+				fmt.Fprint(w, tt.responseBody)
+			}))
+			defer server.Close()
+
+			os.Setenv("GITHUB_API_URL", server.URL)
+			defer os.Unsetenv("GITHUB_API_URL")
+
+			permission, err := getCollaboratorPermission("test/repo", "octocat", "test-token")
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if permission != tt.expected {
+				t.Errorf("Permission = %q, want %q", permission, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name               string
+		commentBody        string
+		permissionResponse string
+		expectedCommand    string
+		expectedAuthorized bool
+		expectedReactions  []string
+	}{
+		{
+			name:               "authorized command",
+			commentBody:        "/deploy staging",
+			permissionResponse: `{"permission": "admin", "role_name": "admin"}`,
+			expectedCommand:    "deploy",
+			expectedAuthorized: true,
+			expectedReactions:  []string{"eyes", "+1"},
+		},
+		{
+			name:               "unauthorized command",
+			commentBody:        "/deploy staging",
+			permissionResponse: `{"permission": "read", "role_name": "read"}`,
+			expectedCommand:    "deploy",
+			expectedAuthorized: false,
+			expectedReactions:  []string{"eyes", "-1"},
+		},
+		{
+			name:               "no command",
+			commentBody:        "Nice work!",
+			expectedCommand:    "",
+			expectedAuthorized: false,
+			expectedReactions:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var reactions []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/reactions") {
+					var request ReactionRequest
+					json.NewDecoder(r.Body).Decode(&request)
+					mu.Lock()
+					reactions = append(reactions, request.Content)
+					mu.Unlock()
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"id": 1}`)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, tt.permissionResponse)
+			}))
+			defer server.Close()
+
+			os.Setenv("GITHUB_API_URL", server.URL)
+			defer os.Unsetenv("GITHUB_API_URL")
+
+			eventPath := writeEvent(t, tt.commentBody)
+
+			result := run(&Config{
+				Repository:         "test/repo",
+				EventPath:          eventPath,
+				RequiredPermission: "write",
+				Token:              "test-token",
+			})
+
+			if result.Error != nil {
+				t.Fatalf("Unexpected error: %v", result.Error)
+			}
+
+			command := ""
+			if result.Command != nil {
+				command = result.Command.Name
+			}
+			if command != tt.expectedCommand {
+				t.Errorf("Command = %q, want %q", command, tt.expectedCommand)
+			}
+			if result.Authorized != tt.expectedAuthorized {
+				t.Errorf("Authorized = %v, want %v", result.Authorized, tt.expectedAuthorized)
+			}
+			if !reflect.DeepEqual(reactions, tt.expectedReactions) {
+				t.Errorf("Reactions = %v, want %v", reactions, tt.expectedReactions)
+			}
+		})
+	}
+}
+
+func TestReadEventWithoutComment(t *testing.T) {
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	os.WriteFile(eventPath, []byte(`{"action": "opened", "issue": {"number": 1}}`), 0644)
+
+	_, err := readEvent(eventPath)
+	if err == nil || !strings.Contains(err.Error(), "issue_comment") {
+		t.Errorf("Expected issue_comment error, got %v", err)
+	}
+}
+
+// writeEvent writes an issue_comment event payload to a temp file and returns its path
+func writeEvent(t *testing.T, commentBody string) string {
+	t.Helper()
+
+	event := map[string]any{
+		"action": "created",
+		"issue":  map[string]any{"number": 7},
+		"comment": map[string]any{
+			"id":   42,
+			"body": commentBody,
+			"user": map[string]any{"login": "octocat"},
+		},
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Failed to encode event: %v", err)
+	}
+
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(eventPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write event: %v", err)
+	}
+
+	return eventPath
+}
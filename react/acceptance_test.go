@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAcceptanceReactToComment(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && strings.Contains(r.URL.Path, "/issues/comments/456/reactions") {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1, "content": "confused"}`)
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_COMMENT_ID":   "456",
+		"INPUT_REACTIONS":    "confused",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stdout, "Added confused to comment 456") {
+		t.Errorf("Expected stdout to contain 'Added confused to comment 456', got: %s", stdout)
+	}
+
+	if stderr != "" {
+		t.Errorf("Expected empty stderr, got: %s", stderr)
+	}
+}
+
+func TestAcceptanceReactToIssue(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && strings.Contains(r.URL.Path, "/issues/123/reactions") {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+		} else {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_ISSUE_NUMBER": "123",
+		"INPUT_REACTIONS":    "+1,rocket",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stdout, "Added +1, rocket to issue #123") {
+		t.Errorf("Expected stdout to contain 'Added +1, rocket to issue #123', got: %s", stdout)
+	}
+}
+
+func TestAcceptanceReactMissingTarget(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup environment with neither issue number nor comment ID
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_REACTIONS":    "eyes",
+		"INPUT_GITHUB_TOKEN": "test-token",
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	if !strings.Contains(stderr, "issue-number or comment-id input is required") {
+		t.Errorf("Expected stderr to contain 'issue-number or comment-id input is required', got: %s", stderr)
+	}
+}
+
+func TestAcceptanceReactAPIError(t *testing.T) {
+	// Build the binary first
+	binaryPath := buildBinary(t)
+	defer os.Remove(binaryPath)
+
+	// Setup test server that returns not found
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}))
+	defer server.Close()
+
+	// Setup environment
+	oldEnv := setupEnv(map[string]string{
+		"GITHUB_REPOSITORY":  "test/repo",
+		"INPUT_COMMENT_ID":   "999",
+		"INPUT_REACTIONS":    "eyes",
+		"INPUT_GITHUB_TOKEN": "test-token",
+		"GITHUB_API_URL":     server.URL,
+	})
+	defer restoreEnv(oldEnv)
+
+	// Execute the binary
+	cmd := exec.Command(binaryPath)
+	cmd.Env = os.Environ()
+
+	stdout, stderr, exitCode := runCommand(cmd)
+
+	// Assertions
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+		t.Logf("Stdout: %s", stdout)
+		t.Logf("Stderr: %s", stderr)
+	}
+
+	expectedStderr := []string{"error adding eyes reaction to comment 999", "API request failed with status 404"}
+	for _, expected := range expectedStderr {
+		if !strings.Contains(stderr, expected) {
+			t.Errorf("Expected stderr to contain %q, got: %s", expected, stderr)
+		}
+	}
+}
+
+// setupEnv sets environment variables and returns the old values for restoration
+func setupEnv(envVars map[string]string) map[string]string {
+	oldEnv := make(map[string]string)
+	for key, value := range envVars {
+		oldEnv[key] = os.Getenv(key)
+		os.Setenv(key, value)
+	}
+	return oldEnv
+}
+
+// restoreEnv restores environment variables to their previous values
+func restoreEnv(oldEnv map[string]string) {
+	for key, value := range oldEnv {
+		if value == "" {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, value)
+		}
+	}
+}
+
+// buildBinary builds the react binary and returns its path
+func buildBinary(t *testing.T) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	binaryPath := filepath.Join(tempDir, "react")
+
+	cmd := exec.Command("go", "build", "-o", binaryPath, "main.go")
+	cmd.Dir = "." // Current directory should be react/
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Failed to build binary: %v\nOutput: %s", err, output)
+	}
+
+	return binaryPath
+}
+
+// runCommand executes a command and returns stdout, stderr, and exit code
+func runCommand(cmd *exec.Cmd) (stdout, stderr string, exitCode int) {
+	stdoutBytes, stderrBytes, err := runCommandBytes(cmd)
+	stdout = string(stdoutBytes)
+	stderr = string(stderrBytes)
+
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			exitCode = exitError.ExitCode()
+		} else {
+			exitCode = -1 // Some other error
+		}
+	} else {
+		exitCode = 0
+	}
+
+	return stdout, stderr, exitCode
+}
+
+// runCommandBytes executes a command and returns stdout and stderr as bytes
+func runCommandBytes(cmd *exec.Cmd) (stdout, stderr []byte, err error) {
+	stdoutBuf := &strings.Builder{}
+	stderrBuf := &strings.Builder{}
+
+	cmd.Stdout = stdoutBuf
+	cmd.Stderr = stderrBuf
+
+	err = cmd.Run()
+	stdout = []byte(stdoutBuf.String())
+	stderr = []byte(stderrBuf.String())
+
+	return stdout, stderr, err
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/half-ogre/go-kit/actionskit"
+)
+
+type ReactionRequest struct {
+	Content string `json:"content"`
+}
+
+// Config holds the configuration for the react action
+type Config struct {
+	Repository  string
+	IssueNumber string
+	CommentID   string
+	Reactions   []string
+	Token       string
+}
+
+// Result holds the result of the react action
+type Result struct {
+	Target  string
+	Success bool
+	Error   error
+}
+
+// validReactions lists the reaction contents accepted by the GitHub reactions API
+var validReactions = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// repositoryPattern matches an owner/name repository reference
+var repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+func main() {
+	config, err := getConfigFromEnvironment()
+	if err != nil {
+		actionskit.Error(err.Error())
+		os.Exit(1)
+	}
+
+	result := run(config)
+	if result.Error != nil {
+		actionskit.Error(result.Error.Error())
+		os.Exit(1)
+	}
+
+	actionskit.Info(fmt.Sprintf("Added %s to %s", strings.Join(config.Reactions, ", "), result.Target))
+}
+
+// getConfigFromEnvironment reads configuration from environment variables and GitHub Actions inputs
+func getConfigFromEnvironment() (*Config, error) {
+	repository := actionskit.GetInput("repository")
+	if repository == "" {
+		repository = os.Getenv("GITHUB_REPOSITORY")
+	}
+	if repository == "" {
+		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
+	}
+	if !repositoryPattern.MatchString(repository) {
+		return nil, fmt.Errorf("repository must be in owner/name format, got %q", repository)
+	}
+
+	issueNumber := actionskit.GetInput("issue-number")
+	commentID := actionskit.GetInput("comment-id")
+	if issueNumber == "" && commentID == "" {
+		return nil, fmt.Errorf("issue-number or comment-id input is required")
+	}
+
+	reactions, err := parseReactions(actionskit.GetInput("reactions"))
+	if err != nil {
+		return nil, err
+	}
+	if len(reactions) == 0 {
+		return nil, fmt.Errorf("reactions input is required")
+	}
+
+	token := actionskit.GetInput("github-token")
+	if token == "" {
+		return nil, fmt.Errorf("github-token input is required")
+	}
+
+	return &Config{
+		Repository:  repository,
+		IssueNumber: issueNumber,
+		CommentID:   commentID,
+		Reactions:   reactions,
+		Token:       token,
+	}, nil
+}
+
+// run executes the react action with the given configuration
+func run(config *Config) *Result {
+	result := &Result{Success: false}
+
+	// A comment ID is more specific than an issue number, so it wins when both are set
+	path := fmt.Sprintf("issues/%s/reactions", config.IssueNumber)
+	result.Target = fmt.Sprintf("issue #%s", config.IssueNumber)
+	if config.CommentID != "" {
+		path = fmt.Sprintf("issues/comments/%s/reactions", config.CommentID)
+		result.Target = fmt.Sprintf("comment %s", config.CommentID)
+	}
+
+	for _, reaction := range config.Reactions {
+		if err := addReaction(config.Repository, path, reaction, config.Token); err != nil {
+			result.Error = fmt.Errorf("error adding %s reaction to %s in %s: %v", reaction, result.Target, config.Repository, err)
+			return result
+		}
+	}
+
+	result.Success = true
+	return result
+}
+
+// parseReactions splits a comma-separated reactions input and validates each entry
+func parseReactions(reactionsInput string) ([]string, error) {
+	var reactions []string
+	for _, reaction := range strings.Split(reactionsInput, ",") {
+		reaction = strings.TrimSpace(reaction)
+		if reaction == "" {
+			continue
+		}
+		valid := false
+		for _, validReaction := range validReactions {
+			if reaction == validReaction {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid reaction %q, must be one of %s", reaction, strings.Join(validReactions, ", "))
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, nil
+}
+
+// addReaction posts a reaction to an issue or comment reactions endpoint relative to the repository
+func addReaction(repository, path, content, token string) error {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s", apiBase, repository, path)
+
+	// Create request body
+	request := ReactionRequest{
+		Content: content,
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body_bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Check status (200 means the reaction already existed)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body_bytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGetConfigFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupEnv    func()
+		cleanupEnv  func()
+		expectError bool
+		errorMsg    string
+		expected    *Config
+	}{
+		{
+			name: "issue target",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_REACTIONS", "+1, rocket")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_REACTIONS")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:  "test/repo",
+				IssueNumber: "123",
+				Reactions:   []string{"+1", "rocket"},
+				Token:       "test-token",
+			},
+		},
+		{
+			name: "comment target",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_COMMENT_ID", "987")
+				os.Setenv("INPUT_REACTIONS", "eyes")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_COMMENT_ID")
+				os.Unsetenv("INPUT_REACTIONS")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository: "test/repo",
+				CommentID:  "987",
+				Reactions:  []string{"eyes"},
+				Token:      "test-token",
+			},
+		},
+		{
+			name: "repository input overrides GITHUB_REPOSITORY",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_REPOSITORY", "other/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "5")
+				os.Setenv("INPUT_REACTIONS", "heart")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_REACTIONS")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: false,
+			expected: &Config{
+				Repository:  "other/repo",
+				IssueNumber: "5",
+				Reactions:   []string{"heart"},
+				Token:       "test-token",
+			},
+		},
+		{
+			name: "invalid repository format",
+			setupEnv: func() {
+				os.Setenv("INPUT_REPOSITORY", "other")
+				os.Setenv("INPUT_ISSUE_NUMBER", "5")
+				os.Setenv("INPUT_REACTIONS", "heart")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("INPUT_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_REACTIONS")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    `repository must be in owner/name format, got "other"`,
+		},
+		{
+			name: "missing target",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_REACTIONS", "eyes")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_REACTIONS")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    "issue-number or comment-id input is required",
+		},
+		{
+			name: "missing reactions",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_REACTIONS", " , ")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_REACTIONS")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    "reactions input is required",
+		},
+		{
+			name: "invalid reaction",
+			setupEnv: func() {
+				os.Setenv("GITHUB_REPOSITORY", "test/repo")
+				os.Setenv("INPUT_ISSUE_NUMBER", "123")
+				os.Setenv("INPUT_REACTIONS", "tada")
+				os.Setenv("INPUT_GITHUB_TOKEN", "test-token")
+			},
+			cleanupEnv: func() {
+				os.Unsetenv("GITHUB_REPOSITORY")
+				os.Unsetenv("INPUT_ISSUE_NUMBER")
+				os.Unsetenv("INPUT_REACTIONS")
+				os.Unsetenv("INPUT_GITHUB_TOKEN")
+			},
+			expectError: true,
+			errorMsg:    `invalid reaction "tada", must be one of +1, -1, laugh, confused, heart, hooray, rocket, eyes`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupEnv()
+			defer tt.cleanupEnv()
+
+			config, err := getConfigFromEnvironment()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				} else if err.Error() != tt.errorMsg {
+					t.Errorf("Expected error message %q, got %q", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("Config = %+v, want %+v", *config, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *Config
+		responseCode   int
+		expectedPath   string
+		expectedTarget string
+		expectError    bool
+	}{
+		{
+			name: "react to issue",
+			config: &Config{
+				IssueNumber: "123",
+				Reactions:   []string{"rocket"},
+			},
+			responseCode:   http.StatusCreated,
+			expectedPath:   "/repos/test/repo/issues/123/reactions",
+			expectedTarget: "issue #123",
+		},
+		{
+			name: "comment id takes precedence",
+			config: &Config{
+				IssueNumber: "123",
+				CommentID:   "987",
+				Reactions:   []string{"-1"},
+			},
+			responseCode:   http.StatusOK,
+			expectedPath:   "/repos/test/repo/issues/comments/987/reactions",
+			expectedTarget: "comment 987",
+		},
+		{
+			name: "API error",
+			config: &Config{
+				CommentID: "987",
+				Reactions: []string{"eyes"},
+			},
+			responseCode: http.StatusNotFound,
+			expectedPath: "/repos/test/repo/issues/comments/987/reactions",
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("Expected POST method, got %s", r.Method)
+				}
+				if r.URL.Path != tt.expectedPath {
+					t.Errorf("Expected path %s, got %s", tt.expectedPath, r.URL.Path)
+				}
+
+				var request ReactionRequest
+				json.NewDecoder(r.Body).Decode(&request)
+				if request.Content != tt.config.Reactions[0] {
+					t.Errorf("Expected content %q, got %q", tt.config.Reactions[0], request.Content)
+				}
+
+				w.WriteHeader(tt.responseCode)
+				fmt.Fprint(w, `{"id": 1}`)
+			}))
+			defer server.Close()
+
+			os.Setenv("GITHUB_API_URL", server.URL)
+			defer os.Unsetenv("GITHUB_API_URL")
+
+			tt.config.Repository = "test/repo"
+			tt.config.Token = "test-token"
+
+			result := run(tt.config)
+
+			if tt.expectError {
+				if result.Error == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if result.Error != nil {
+				t.Errorf("Unexpected error: %v", result.Error)
+			}
+			if result.Target != tt.expectedTarget {
+				t.Errorf("Target = %q, want %q", result.Target, tt.expectedTarget)
+			}
+		})
+	}
+}
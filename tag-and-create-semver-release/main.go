@@ -1,36 +1,196 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	neturl "net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/half-ogre/go-kit/actionskit"
+	"github.com/half-ogre/go-kit/versionkit"
 	"github.com/half-ogre-games/hog-actions/internal/semveractions"
 )
 
 // Config holds the configuration for the tag-and-create-semver-release action
 type Config struct {
-	Branch           string
-	Commit           string
-	IncrementMajor   bool
-	IncrementMinor   bool
-	Prefix           string
-	DefaultVersion   string
-	GitHubToken      string
-	DefaultBranch    string // Will be populated from GitHub context
+	Branch               string
+	Commit               string
+	Increment            string
+	IncrementSource      string
+	MajorLabel           string
+	MinorLabel           string
+	PatchLabel           string
+	SkipLabel            string
+	Repository           string
+	Preid                string
+	Prefix               string
+	BuildMetadata        string // Template rendered into build-version, never into the tag
+	ReleaseNotes         string // text/template for the release notes, from release-notes-template
+	Changelog            string // none, file or commit
+	ChangelogPath        string
+	Assets               []string // Glob patterns of files to upload to the release
+	ContinueOnAssetError bool     // Warn instead of failing when an asset upload fails
+	Draft                bool
+	MakeLatest           string // true, false or auto
+	FloatingTags         string // none, major or major-minor
+	FloatMajorZero       bool   // Also float tags for 0.x versions
+	Rollback             bool   // Undo pushed tags when the release fails
+	DryRun               bool   // Preview the release without tagging or releasing
+	TaggerName           string
+	TaggerEmail          string
+	TaggerFromToken      bool // Fill an unset tagger name or email from the token's user
+	SignTags             bool
+	SigningKey           string // Armored GPG or OpenSSH private key
+	SigningKeyPassphrase string // gpg only
+	SigningFormat        string // gpg or ssh
+	TagMessage           string // text/template for the version tag's message
+	FloatingTagMessage   string // text/template for floating tags' messages
+	LightweightTags      bool   // Create tags without messages instead of annotated tags
+	DefaultVersion       string
+	GitHubToken          string
+	DefaultBranch        string // Will be populated from GitHub context
+}
+
+// PullRequest is the subset of a GitHub pull request used to resolve label
+// increments and write release notes
+type PullRequest struct {
+	Number   int     `json:"number"`
+	Title    string  `json:"title"`
+	HTMLURL  string  `json:"html_url"`
+	MergedAt *string `json:"merged_at"`
+	User     User    `json:"user"`
+	Labels   []Label `json:"labels"`
+}
+
+// CreateReleaseRequest represents the request body for creating a GitHub release
+type CreateReleaseRequest struct {
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish"`
+	Name            string `json:"name"`
+	Body            string `json:"body"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+	MakeLatest      string `json:"make_latest"`
+}
+
+// Release represents a GitHub release
+type Release struct {
+	ID         int64  `json:"id"`
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	HTMLURL    string `json:"html_url"`
+	UploadURL  string `json:"upload_url"`
+}
+
+// Asset represents an uploaded GitHub release asset
+type Asset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	ContentType        string `json:"content_type"`
+	Size               int64  `json:"size"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// assetContentTypes maps common release asset extensions to their content types,
+// ahead of the system MIME table
+var assetContentTypes = map[string]string{
+	".deb":    "application/vnd.debian.binary-package",
+	".dmg":    "application/x-apple-diskimage",
+	".gz":     "application/gzip",
+	".json":   "application/json",
+	".md":     "text/markdown",
+	".rpm":    "application/x-rpm",
+	".sha256": "text/plain",
+	".tar":    "application/x-tar",
+	".tgz":    "application/gzip",
+	".txt":    "text/plain",
+	".xz":     "application/x-xz",
+	".zip":    "application/zip",
+}
+
+// checksumsAssetName is the name of the checksums file attached alongside assets
+const checksumsAssetName = "SHA256SUMS"
+
+// FloatingTag is a tag such as v1 or v1.4 that moves to the latest release in its line
+type FloatingTag struct {
+	Name     string
+	Level    string // major or minor
+	Previous string // The tag object on origin before the move, empty for a new tag
+	Message  string
+}
+
+// User represents a GitHub user
+type User struct {
+	Login string `json:"login"`
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+}
+
+// Identity is the name and email that tags and commits are made as
+type Identity struct {
+	Name  string
+	Email string
+}
+
+// The tagger when neither tagger-name, tagger-email nor tagger-from-token is set
+const (
+	defaultTaggerName  = "github-actions[bot]"
+	defaultTaggerEmail = "github-actions[bot]@users.noreply.github.com"
+)
+
+// Label represents a GitHub label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// ChangelogBackup is the changelog file and HEAD as they were before the changelog
+// update, so a failed release can put them back
+type ChangelogBackup struct {
+	Contents []byte
+	Existed  bool   // Whether the changelog file existed
+	Head     string // HEAD before the changelog commit, when one is made
 }
 
 // Result holds the result of the tag-and-create-semver-release action
 type Result struct {
-	PreviousVersion string
-	NewVersion      string
-	IncrementType   string
-	ReleaseURL      string
-	TargetCommit    string
-	Success         bool
-	Error           error
+	PreviousVersion  string
+	NewVersion       string
+	BuildVersion     string
+	Changelog        string           // The changelog section added for the new version
+	ChangelogCommit  string           // The commit that added the changelog section, if committed
+	ChangelogBackup  *ChangelogBackup // What the changelog update replaced, for a failed release to put back
+	IncrementType    string
+	IncrementCommits []semveractions.Commit // Commits that caused an auto increment
+	Prerelease       bool
+	Latest           bool          // Whether the release was marked latest
+	MajorTagUpdated  bool          // Whether the major version tag was moved to the new version
+	FloatingTags     []FloatingTag // The floating tags moved to the new version
+	RolledBack       bool          // Whether pushed tags were undone after a failure
+	Resumed          bool          // Whether an earlier run's tag was reused to finish its release
+	DryRun           bool          // Whether the release was only previewed
+	TagMessage       string
+	ReleaseNotes     string
+	Skipped          bool
+	ReleaseID        int64
+	ReleaseURL       string
+	UploadURL        string
+	Assets           []Asset
+	TargetCommit     string
+	Success          bool
+	Error            error
 }
 
 func main() {
@@ -47,7 +207,15 @@ func main() {
 	}
 
 	// Output results
-	actionskit.Info(fmt.Sprintf("✅ Created tag %s for commit %s", result.NewVersion, result.TargetCommit))
+	if result.Skipped {
+		actionskit.Info(fmt.Sprintf("Increment is none and %s already exists, no tag or release created", result.NewVersion))
+	} else if result.DryRun {
+		actionskit.Info(fmt.Sprintf("Dry run: would create tag %s for commit %s", result.NewVersion, result.TargetCommit))
+	} else if result.Resumed {
+		actionskit.Info(fmt.Sprintf("✅ Resumed release of tag %s for commit %s", result.NewVersion, result.TargetCommit))
+	} else {
+		actionskit.Info(fmt.Sprintf("✅ Created tag %s for commit %s", result.NewVersion, result.TargetCommit))
+	}
 	if result.ReleaseURL != "" {
 		actionskit.Info(fmt.Sprintf("✅ Created release: %s", result.ReleaseURL))
 	}
@@ -75,14 +243,51 @@ func getConfigFromEnvironment() (*Config, error) {
 		commit = "HEAD"
 	}
 
-	incrementMajorStr := actionskit.GetInput("increment-major")
-	incrementMajor := incrementMajorStr == "true"
+	increment, err := semveractions.GetIncrement()
+	if err != nil {
+		return nil, err
+	}
+
+	incrementSource := strings.ToLower(strings.TrimSpace(actionskit.GetInput("increment-source")))
+	if incrementSource == "" {
+		incrementSource = "commits"
+	}
+	if incrementSource != "commits" && incrementSource != "pull-request-labels" {
+		return nil, fmt.Errorf("increment-source must be commits or pull-request-labels, got %q", incrementSource)
+	}
+
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if incrementSource == "pull-request-labels" {
+		if increment != "auto" {
+			return nil, fmt.Errorf("increment-source pull-request-labels requires increment: auto")
+		}
+		if repository == "" {
+			return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable is required for increment-source pull-request-labels")
+		}
+	}
+
+	majorLabel := actionskit.GetInput("major-label")
+	if majorLabel == "" {
+		majorLabel = "semver:major"
+	}
+
+	minorLabel := actionskit.GetInput("minor-label")
+	if minorLabel == "" {
+		minorLabel = "semver:minor"
+	}
+
+	patchLabel := actionskit.GetInput("patch-label")
+	if patchLabel == "" {
+		patchLabel = "semver:patch"
+	}
 
-	incrementMinorStr := actionskit.GetInput("increment-minor")
-	incrementMinor := incrementMinorStr == "true"
+	skipLabel := actionskit.GetInput("skip-label")
+	if skipLabel == "" {
+		skipLabel = "semver:skip"
+	}
 
-	// Validate increment flags
-	if err := semveractions.ValidateIncrementFlags(incrementMajor, incrementMinor); err != nil {
+	preid := actionskit.GetInput("preid")
+	if err := semveractions.ValidatePreid(preid); err != nil {
 		return nil, err
 	}
 
@@ -91,31 +296,173 @@ func getConfigFromEnvironment() (*Config, error) {
 		prefix = "v"
 	}
 
+	releaseNotes := defaultReleaseNotesTemplate
+	if releaseNotesTemplate := actionskit.GetInput("release-notes-template"); releaseNotesTemplate != "" {
+		text, err := os.ReadFile(releaseNotesTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release-notes-template: %v", err)
+		}
+		releaseNotes = string(text)
+	}
+	if _, err := renderReleaseNotes(releaseNotes, &ReleaseNotes{}); err != nil {
+		return nil, err
+	}
+
+	buildMetadata := actionskit.GetInput("build-metadata")
+	if buildMetadata != "" {
+		if err := semveractions.ValidateBuildMetadataTemplate(buildMetadata); err != nil {
+			return nil, err
+		}
+	}
+
+	changelog := strings.ToLower(strings.TrimSpace(actionskit.GetInput("changelog")))
+	if changelog == "" {
+		changelog = "none"
+	}
+	if changelog != "none" && changelog != "file" && changelog != "commit" {
+		return nil, fmt.Errorf("changelog must be none, file or commit, got %q", changelog)
+	}
+	if changelog == "commit" && commit != "HEAD" {
+		return nil, fmt.Errorf("changelog commit requires commit: HEAD, got %q", commit)
+	}
+
+	changelogPath := actionskit.GetInput("changelog-path")
+	if changelogPath == "" {
+		changelogPath = "CHANGELOG.md"
+	}
+
 	defaultVersion := actionskit.GetInput("default-version")
 	if defaultVersion == "" {
 		defaultVersion = "v0.1.0"
 	}
 
+	// Assets are glob patterns separated by newlines or commas
+	var assets []string
+	for _, pattern := range strings.FieldsFunc(actionskit.GetInput("assets"), func(r rune) bool {
+		return r == '\n' || r == ','
+	}) {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			assets = append(assets, pattern)
+		}
+	}
+
+	continueOnAssetError := strings.EqualFold(actionskit.GetInput("continue-on-asset-error"), "true")
+
+	draft := strings.EqualFold(actionskit.GetInput("draft"), "true")
+
+	makeLatest := strings.ToLower(strings.TrimSpace(actionskit.GetInput("make-latest")))
+	if makeLatest == "" {
+		makeLatest = "auto"
+	}
+	if makeLatest != "true" && makeLatest != "false" && makeLatest != "auto" {
+		return nil, fmt.Errorf("make-latest must be true, false or auto, got %q", makeLatest)
+	}
+
+	floatingTags := strings.ToLower(strings.TrimSpace(actionskit.GetInput("floating-tags")))
+	if floatingTags == "" {
+		floatingTags = "major"
+	}
+	if floatingTags != "none" && floatingTags != "major" && floatingTags != "major-minor" {
+		return nil, fmt.Errorf("floating-tags must be none, major or major-minor, got %q", floatingTags)
+	}
+
+	floatMajorZero := strings.EqualFold(actionskit.GetInput("float-major-zero"), "true")
+
+	rollback := !strings.EqualFold(actionskit.GetInput("rollback"), "false")
+
+	dryRun := strings.EqualFold(actionskit.GetInput("dry-run"), "true")
+
+	taggerName := strings.TrimSpace(actionskit.GetInput("tagger-name"))
+	taggerEmail := strings.TrimSpace(actionskit.GetInput("tagger-email"))
+	taggerFromToken := strings.EqualFold(actionskit.GetInput("tagger-from-token"), "true")
+
+	signTags := strings.EqualFold(actionskit.GetInput("sign-tags"), "true")
+	signingKey := actionskit.GetInput("signing-key")
+	signingKeyPassphrase := actionskit.GetInput("signing-key-passphrase")
+
+	signingFormat := strings.ToLower(strings.TrimSpace(actionskit.GetInput("signing-format")))
+	if signingFormat == "" {
+		signingFormat = "gpg"
+	}
+	if signingFormat != "gpg" && signingFormat != "ssh" {
+		return nil, fmt.Errorf("signing-format must be gpg or ssh, got %q", signingFormat)
+	}
+	if signTags && strings.TrimSpace(signingKey) == "" {
+		return nil, fmt.Errorf("signing-key is required when sign-tags is true")
+	}
+	if signingFormat == "ssh" && signingKeyPassphrase != "" {
+		return nil, fmt.Errorf("signing-key-passphrase is only supported for gpg signing keys")
+	}
+
+	tagMessage := actionskit.GetInput("tag-message")
+	if tagMessage == "" {
+		tagMessage = defaultTagMessageTemplate
+	}
+	if _, err := renderTagMessage("tag-message", tagMessage, TagMessage{}); err != nil {
+		return nil, err
+	}
+
+	floatingTagMessage := actionskit.GetInput("floating-tag-message")
+	if floatingTagMessage == "" {
+		floatingTagMessage = defaultFloatingTagMessageTemplate
+	}
+	if _, err := renderTagMessage("floating-tag-message", floatingTagMessage, TagMessage{}); err != nil {
+		return nil, err
+	}
+
+	lightweightTags := strings.EqualFold(actionskit.GetInput("lightweight-tags"), "true")
+	if lightweightTags && signTags {
+		return nil, fmt.Errorf("sign-tags cannot be used with lightweight-tags, which have no annotation to sign")
+	}
+
 	githubToken, err := actionskit.GetInputRequired("github-token")
 	if err != nil {
 		return nil, err
 	}
 
 	return &Config{
-		Branch:         branch,
-		Commit:         commit,
-		IncrementMajor: incrementMajor,
-		IncrementMinor: incrementMinor,
-		Prefix:         prefix,
-		DefaultVersion: defaultVersion,
-		GitHubToken:    githubToken,
-		DefaultBranch:  branch,
+		Branch:               branch,
+		Commit:               commit,
+		Increment:            increment,
+		IncrementSource:      incrementSource,
+		MajorLabel:           majorLabel,
+		MinorLabel:           minorLabel,
+		PatchLabel:           patchLabel,
+		SkipLabel:            skipLabel,
+		Repository:           repository,
+		Preid:                preid,
+		Prefix:               prefix,
+		BuildMetadata:        buildMetadata,
+		ReleaseNotes:         releaseNotes,
+		Changelog:            changelog,
+		ChangelogPath:        changelogPath,
+		Assets:               assets,
+		ContinueOnAssetError: continueOnAssetError,
+		Draft:                draft,
+		MakeLatest:           makeLatest,
+		FloatingTags:         floatingTags,
+		FloatMajorZero:       floatMajorZero,
+		Rollback:             rollback,
+		DryRun:               dryRun,
+		TaggerName:           taggerName,
+		TaggerEmail:          taggerEmail,
+		TaggerFromToken:      taggerFromToken,
+		SignTags:             signTags,
+		SigningKey:           signingKey,
+		SigningKeyPassphrase: signingKeyPassphrase,
+		SigningFormat:        signingFormat,
+		TagMessage:           tagMessage,
+		FloatingTagMessage:   floatingTagMessage,
+		LightweightTags:      lightweightTags,
+		DefaultVersion:       defaultVersion,
+		GitHubToken:          githubToken,
+		DefaultBranch:        branch,
 	}, nil
 }
 
 // run executes the tag-and-create-semver-release action with the given configuration
 func run(config *Config) *Result {
-	result := &Result{Success: false}
+	result := &Result{Success: false, DryRun: config.DryRun}
 
 	// Step 1: Get target commit SHA
 	targetCommit, err := getTargetCommitSHA(config.Commit)
@@ -138,6 +485,37 @@ func run(config *Config) *Result {
 		return result
 	}
 
+	// A latest tag on the target commit without a release is left over from an earlier
+	// run that failed part way; version from the tags before it and finish the release
+	resumeTag := ""
+	if found && config.Repository != "" && config.Increment != "none" {
+		tagCommit, resumable, err := findPartialRelease(config, latestTag, targetCommit)
+		if err != nil {
+			result.Error = fmt.Errorf("error checking for a partial release: %v", err)
+			return result
+		}
+
+		if resumable {
+			resumeTag = latestTag
+			result.Resumed = true
+			result.TargetCommit = tagCommit
+			actionskit.Info(fmt.Sprintf("Tag %s already points at %s without a release, resuming", resumeTag, tagCommit))
+
+			var earlierTags []string
+			for _, tag := range tags {
+				if tag != resumeTag {
+					earlierTags = append(earlierTags, tag)
+				}
+			}
+
+			latestTag, found, err = semveractions.FindLatestSemverTag(earlierTags, config.Prefix)
+			if err != nil {
+				result.Error = fmt.Errorf("error finding latest tag: %v", err)
+				return result
+			}
+		}
+	}
+
 	var currentVersion string
 	if !found {
 		currentVersion = config.DefaultVersion
@@ -156,7 +534,61 @@ func run(config *Config) *Result {
 		return result
 	}
 
-	newSemver, incrementType, err := semveractions.IncrementVersion(semver, config.IncrementMajor, config.IncrementMinor)
+	// The commits since the latest tag feed an auto increment, the changelog and the
+	// release notes
+	previousTag := ""
+	if found {
+		previousTag = latestTag
+	}
+	commits, err := semveractions.GetCommitsSince(previousTag, targetCommit)
+	if err != nil {
+		result.Error = fmt.Errorf("error getting commits: %v", err)
+		return result
+	}
+
+	// Pull requests are looked up once per commit, and only for the newest commits of
+	// a first release
+	lookupLimit := 0
+	if previousTag == "" {
+		lookupLimit = firstReleasePullRequestLookups
+		if config.Repository != "" && len(commits) > lookupLimit {
+			actionskit.Info(fmt.Sprintf("First release has %d commits, looking up pull requests for the newest %d only", len(commits), lookupLimit))
+		}
+	}
+	lookup := NewPullRequestLookup(config, lookupLimit)
+
+	// Commits of pull requests with the skip label are left out of the increment,
+	// the changelog and the release notes alike
+	if config.IncrementSource == "pull-request-labels" {
+		commits, err = skipLabeledCommits(commits, config, lookup)
+		if err != nil {
+			result.Error = fmt.Errorf("error checking pull requests for the skip label: %v", err)
+			return result
+		}
+	}
+
+	increment := config.Increment
+
+	// Resolve an auto increment from the commits since the latest tag, using either
+	// their Conventional Commits messages or the labels of their merged pull requests
+	if increment == "auto" {
+		if config.IncrementSource == "pull-request-labels" {
+			increment, result.IncrementCommits, err = labelIncrement(commits, config, lookup)
+			if err != nil {
+				result.Error = fmt.Errorf("error resolving increment from pull request labels: %v", err)
+				return result
+			}
+		} else {
+			increment, result.IncrementCommits = semveractions.AutoIncrement(commits, semver)
+		}
+
+		actionskit.Info(fmt.Sprintf("Auto increment resolved to %s from %d commit(s)", increment, len(result.IncrementCommits)))
+		for _, commit := range result.IncrementCommits {
+			actionskit.Info(fmt.Sprintf("  %s %s", commit.SHA, commit.Subject))
+		}
+	}
+
+	newSemver, err := semveractions.IncrementVersion(semver, increment, config.Preid)
 	if err != nil {
 		result.Error = fmt.Errorf("error incrementing version: %v", err)
 		return result
@@ -164,30 +596,217 @@ func run(config *Config) *Result {
 
 	newVersionTag := semveractions.FormatVersionWithPrefix(newSemver, config.Prefix)
 	result.NewVersion = newVersionTag
-	result.IncrementType = incrementType
+	result.IncrementType = increment
+	result.Prerelease = newSemver.PreReleaseVersion != ""
+
+	actionskit.Info(fmt.Sprintf("Next version: %s (%s increment)", newVersionTag, increment))
+
+	// The tag carries only the core version; build metadata goes into build-version
+	result.BuildVersion = newVersionTag
+	if config.BuildMetadata != "" {
+		data, err := semveractions.GetBuildMetadata(targetCommit)
+		if err != nil {
+			result.Error = fmt.Errorf("error getting build metadata: %v", err)
+			return result
+		}
 
-	actionskit.Info(fmt.Sprintf("Next version: %s (%s increment)", newVersionTag, incrementType))
+		buildSemver := *newSemver
+		buildSemver.BuildMetadata, err = semveractions.RenderBuildMetadata(config.BuildMetadata, *data)
+		if err != nil {
+			result.Error = fmt.Errorf("error rendering build metadata: %v", err)
+			return result
+		}
+
+		result.BuildVersion = semveractions.FormatVersionWithPrefix(&buildSemver, config.Prefix)
+		actionskit.Info(fmt.Sprintf("Build version: %s", result.BuildVersion))
+	}
 
-	// Step 4: Check if tag already exists
-	if tagExists(newVersionTag) {
+	// A none increment of an existing tag has nothing to release
+	if increment == "none" && found && newVersionTag == latestTag {
+		result.Skipped = true
+		result.Success = true
+		return result
+	}
+
+	// Step 4: Check if tag already exists, and that its release can be created
+	if resumeTag != "" && newVersionTag != resumeTag {
+		result.Error = fmt.Errorf("tag %s from an earlier run does not match the computed version %s", resumeTag, newVersionTag)
+		return result
+	}
+	if resumeTag == "" && tagExists(newVersionTag) {
 		result.Error = fmt.Errorf("tag %s already exists", newVersionTag)
 		return result
 	}
+	if config.Repository == "" {
+		result.Error = fmt.Errorf("GITHUB_REPOSITORY environment variable is required to create a release")
+		return result
+	}
+
+	// Resolve the assets before anything is pushed, so a missing file fails early
+	assetFiles, err := resolveAssets(config.Assets)
+	if err != nil {
+		result.Error = fmt.Errorf("error resolving assets: %v", err)
+		return result
+	}
+
+	tagger, err := resolveTagger(config)
+	if err != nil {
+		result.Error = fmt.Errorf("error resolving tagger: %v", err)
+		return result
+	}
+
+	// Step 5: Add the changelog section, committing it ahead of the tag if asked to.
+	// Until the tags are pushed a failure leaves nothing on origin, so the changelog
+	// and its commit are put back; after that, rollbackRelease does so.
+	pushed := false
+	if config.Changelog != "none" {
+		defer func() {
+			if result.Error == nil || pushed {
+				return
+			}
+			if err := restoreChangelog(config, result); err != nil {
+				result.Error = fmt.Errorf("%v; failed to restore %s: %v", result.Error, config.ChangelogPath, err)
+			}
+		}()
+
+		if err := updateChangelog(config, result, tagger, newSemver, previousTag, commits); err != nil {
+			result.Error = fmt.Errorf("error updating changelog: %v", err)
+			return result
+		}
+		targetCommit = result.TargetCommit
+	}
+
+	// Step 6: Create and push tags, only moving floating tags forward within their line.
+	// Tags on origin count too, since a release branch checkout may not have them all.
+	remoteTags, err := getRemoteTags()
+	if err != nil {
+		result.Error = fmt.Errorf("error getting remote tags: %v", err)
+		return result
+	}
+	for tag := range remoteTags {
+		tags = append(tags, tag)
+	}
+	result.FloatingTags = resolveFloatingTags(tags, config, newSemver)
+	for i, floatingTag := range result.FloatingTags {
+		result.FloatingTags[i].Previous = remoteTags[floatingTag.Name]
+	}
+
+	// Render the tag messages, which lightweight tags do without
+	if !config.LightweightTags {
+		message := TagMessage{
+			Tag:             newVersionTag,
+			Version:         semveractions.FormatVersionWithPrefix(newSemver, ""),
+			Major:           newSemver.MajorVersion,
+			Minor:           newSemver.MinorVersion,
+			Patch:           newSemver.PatchVersion,
+			Prerelease:      newSemver.PreReleaseVersion,
+			PreviousVersion: result.PreviousVersion,
+			IncrementType:   result.IncrementType,
+			Commit:          targetCommit,
+			ShortCommit:     semveractions.Commit{SHA: targetCommit}.ShortSHA(),
+			Shortlog:        formatShortlog(commits),
+		}
+
+		result.TagMessage, err = renderTagMessage("tag-message", config.TagMessage, message)
+		if err != nil {
+			result.Error = fmt.Errorf("error rendering tag message: %v", err)
+			return result
+		}
+
+		for i, floatingTag := range result.FloatingTags {
+			message.FloatingTag = floatingTag.Name
+			message.Level = floatingTag.Level
+			result.FloatingTags[i].Message, err = renderTagMessage("floating-tag-message", config.FloatingTagMessage, message)
+			if err != nil {
+				result.Error = fmt.Errorf("error rendering tag message: %v", err)
+				return result
+			}
+		}
+	}
+
+	// The release notes and latest marking are settled before anything is pushed
+	notes, err := buildReleaseNotes(config, result, previousTag, commits, lookup)
+	if err != nil {
+		result.Error = fmt.Errorf("error building release notes: %v", err)
+		return result
+	}
+
+	releaseNotes, err := renderReleaseNotes(config.ReleaseNotes, notes)
+	if err != nil {
+		result.Error = fmt.Errorf("error rendering release notes: %v", err)
+		return result
+	}
+	result.ReleaseNotes = releaseNotes
+
+	result.Latest, err = resolveMakeLatest(config, result, newSemver)
+	if err != nil {
+		result.Error = fmt.Errorf("error deciding whether the release is latest: %v", err)
+		return result
+	}
+
+	// A dry run stops here, previewing the release in the job summary
+	if config.DryRun {
+		if err := writeJobSummary(renderDryRunSummary(result)); err != nil {
+			result.Error = fmt.Errorf("error writing job summary: %v", err)
+			return result
+		}
+		result.Success = true
+		return result
+	}
+
+	// Set up the signing key for this run only, removing it once the tags exist
+	var signer *Signer
+	if config.SignTags {
+		signer, err = setupSigner(config, tagger)
+		if err != nil {
+			result.Error = fmt.Errorf("error setting up tag signing: %v", err)
+			return result
+		}
+	}
+
+	// A changelog commit made by this run is pushed to the branch with the tags
+	changelogBranch := ""
+	if result.ChangelogCommit != "" && !result.Resumed {
+		changelogBranch = config.Branch
+	}
 
-	// Step 5: Create and push tags
-	if err := createAndPushTags(newVersionTag, targetCommit, int(newSemver.MajorVersion)); err != nil {
+	err = createAndPushTags(newVersionTag, result.TagMessage, targetCommit, changelogBranch, result.FloatingTags, tagger, signer, config.LightweightTags, result.Resumed)
+	signer.Cleanup()
+	if err != nil {
 		result.Error = fmt.Errorf("error creating tags: %v", err)
 		return result
 	}
+	pushed = true
 
-	// Step 6: Create GitHub release
-	releaseURL, err := createGitHubRelease(config, result)
+	// The major tag only counts as updated once it is on origin
+	for _, floatingTag := range result.FloatingTags {
+		if floatingTag.Level == "major" {
+			result.MajorTagUpdated = true
+		}
+	}
+
+	// Step 7: Create GitHub release, rolling the tags back if it or an upload fails
+	release, err := createGitHubRelease(config, result, releaseNotes)
 	if err != nil {
 		result.Error = fmt.Errorf("error creating release: %v", err)
+		rollbackRelease(config, result)
 		return result
 	}
 
-	result.ReleaseURL = releaseURL
+	result.ReleaseID = release.ID
+	result.ReleaseURL = release.HTMLURL
+	result.UploadURL = release.UploadURL
+
+	// Step 8: Upload assets and their checksums
+	if len(assetFiles) > 0 {
+		result.Assets, err = uploadAssets(config, release.UploadURL, assetFiles)
+		if err != nil {
+			result.Error = fmt.Errorf("error uploading assets: %v", err)
+			rollbackRelease(config, result)
+			return result
+		}
+	}
+
 	result.Success = true
 	return result
 }
@@ -212,6 +831,44 @@ func getTargetCommitSHA(commit string) (string, error) {
 	return commit, nil
 }
 
+// findPartialRelease checks whether a tag was left by an earlier run that failed
+// before its release was created: the tag points at the target commit, or at the
+// changelog commit made on top of it, and has no release. It returns the tag's commit.
+func findPartialRelease(config *Config, tag, targetCommit string) (string, bool, error) {
+	output, err := exec.Command("git", "rev-parse", tag+"^{commit}").Output()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to resolve tag %s: %v", tag, err)
+	}
+	tagCommit := strings.TrimSpace(string(output))
+
+	if tagCommit != targetCommit {
+		if config.Changelog != "commit" {
+			return "", false, nil
+		}
+
+		output, err := exec.Command("git", "log", "-1", "--format=%P%x1f%s", tagCommit).Output()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read commit %s: %v", tagCommit, err)
+		}
+		parents, subject, _ := strings.Cut(strings.TrimSpace(string(output)), "\x1f")
+		if parents != targetCommit || subject != fmt.Sprintf("chore(release): %s", tag) {
+			return "", false, nil
+		}
+	}
+
+	releases, err := listReleases(config.Repository, config.GitHubToken)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list releases: %v", err)
+	}
+	for _, release := range releases {
+		if release.TagName == tag {
+			return "", false, nil
+		}
+	}
+
+	return tagCommit, true, nil
+}
+
 // tagExists checks if a git tag already exists
 func tagExists(tag string) bool {
 	if tag == "" {
@@ -225,115 +882,1505 @@ func tagExists(tag string) bool {
 	return strings.TrimSpace(string(output)) == tag
 }
 
-// createAndPushTags creates and pushes the semver tag and major version tag
-func createAndPushTags(newVersionTag, targetCommit string, majorVersion int) error {
-	// Configure git user
-	if err := exec.Command("git", "config", "user.name", "github-actions[bot]").Run(); err != nil {
-		return fmt.Errorf("failed to configure git user name: %v", err)
+// resolveTagger returns the identity to tag and commit as. With tagger-from-token it
+// looks up the token's user for whichever of tagger-name and tagger-email is not set.
+func resolveTagger(config *Config) (Identity, error) {
+	tagger := Identity{Name: config.TaggerName, Email: config.TaggerEmail}
+	if !config.TaggerFromToken || (tagger.Name != "" && tagger.Email != "") {
+		if tagger.Name == "" {
+			tagger.Name = defaultTaggerName
+		}
+		if tagger.Email == "" {
+			tagger.Email = defaultTaggerEmail
+		}
+		return tagger, nil
 	}
-	if err := exec.Command("git", "config", "user.email", "github-actions[bot]@users.noreply.github.com").Run(); err != nil {
-		return fmt.Errorf("failed to configure git user email: %v", err)
+
+	user, err := getAuthenticatedUser(config.GitHubToken)
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to look up the token's user: %v", err)
 	}
 
-	// Create and push the semver tag
-	cmd := exec.Command("git", "tag", "-a", newVersionTag, targetCommit, "-m", fmt.Sprintf("Release %s", newVersionTag))
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to create tag %s: %v", newVersionTag, err)
+	if tagger.Name == "" {
+		tagger.Name = user.Name
+		if tagger.Name == "" {
+			tagger.Name = user.Login
+		}
+	}
+	if tagger.Email == "" {
+		// The noreply address links the tag to the account without exposing an email
+		tagger.Email = fmt.Sprintf("%d+%s@users.noreply.github.com", user.ID, user.Login)
 	}
 
-	cmd = exec.Command("git", "push", "origin", newVersionTag)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to push tag %s: %v", newVersionTag, err)
+	return tagger, nil
+}
+
+// gitAs builds a git command that commits and tags as the given identity, through
+// the environment rather than the repository's git config
+func gitAs(identity Identity, args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+identity.Name,
+		"GIT_AUTHOR_EMAIL="+identity.Email,
+		"GIT_COMMITTER_NAME="+identity.Name,
+		"GIT_COMMITTER_EMAIL="+identity.Email,
+	)
+	return cmd
+}
+
+// Signer signs tags with a GPG or SSH key that lives in a temporary directory for
+// the run only, selected through git -c options rather than the repository config
+type Signer struct {
+	Format string   // gpg or ssh
+	Dir    string   // Holds the key; removed by Cleanup
+	Config []string // git -c options selecting the key
+	Env    []string // Environment for git and gpg, such as GNUPGHOME
+}
+
+// setupSigner imports the signing key into a temporary GNUPGHOME for gpg, or writes
+// it to a temporary file for ssh
+func setupSigner(config *Config, tagger Identity) (*Signer, error) {
+	dir, err := os.MkdirTemp("", "tag-signing-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing directory: %v", err)
+	}
+	if err := os.Chmod(dir, 0700); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to secure signing directory: %v", err)
 	}
 
-	actionskit.Info(fmt.Sprintf("✅ Created and pushed tag %s for commit %s", newVersionTag, targetCommit))
+	signer := &Signer{Format: config.SigningFormat, Dir: dir}
+	if config.SigningFormat == "ssh" {
+		err = setupSSHSigner(signer, config.SigningKey, tagger)
+	} else {
+		err = setupGPGSigner(signer, config.SigningKey, config.SigningKeyPassphrase)
+	}
+	if err != nil {
+		signer.Cleanup()
+		return nil, err
+	}
 
-	// Create major version tag if major version is 1 or more
-	if majorVersion >= 1 {
-		majorTag := fmt.Sprintf("v%d", majorVersion)
-		actionskit.Info(fmt.Sprintf("Creating major version tag: %s", majorTag))
+	actionskit.Info(fmt.Sprintf("Signing tags with a %s key", signer.Format))
+	return signer, nil
+}
 
-		// Delete existing major tag if it exists (force update)
-		cmd = exec.Command("git", "tag", "-d", majorTag)
-		cmd.Run() // Ignore error if tag doesn't exist locally
+// setupGPGSigner imports an armored private key and signs through a gpg wrapper
+// that supplies the passphrase without a pinentry prompt
+func setupGPGSigner(signer *Signer, key, passphrase string) error {
+	signer.Env = []string{"GNUPGHOME=" + signer.Dir}
 
-		cmd = exec.Command("git", "push", "origin", ":refs/tags/"+majorTag)
-		cmd.Run() // Ignore error if tag doesn't exist remotely
+	cmd := exec.Command("gpg", "--batch", "--import")
+	cmd.Env = append(os.Environ(), signer.Env...)
+	cmd.Stdin = strings.NewReader(key)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to import signing-key: %v\nOutput: %s", err, string(output))
+	}
 
-		// Create and push new major tag
-		cmd = exec.Command("git", "tag", "-a", majorTag, targetCommit, "-m", fmt.Sprintf("Major version %s (latest: %s)", majorTag, newVersionTag))
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to create major tag %s: %v", majorTag, err)
-		}
+	cmd = exec.Command("gpg", "--batch", "--with-colons", "--list-secret-keys")
+	cmd.Env = append(os.Environ(), signer.Env...)
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to list imported keys: %v", err)
+	}
 
-		cmd = exec.Command("git", "push", "origin", majorTag)
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to push major tag %s: %v", majorTag, err)
+	fingerprint := ""
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Split(line, ":")
+		if fields[0] == "fpr" && len(fields) > 9 {
+			fingerprint = fields[9]
+			break
 		}
+	}
+	if fingerprint == "" {
+		return fmt.Errorf("signing-key does not contain a GPG private key")
+	}
 
-		actionskit.Info(fmt.Sprintf("✅ Created and pushed major version tag %s", majorTag))
-	} else {
-		actionskit.Info("Major version is 0, skipping major version tag creation")
+	program := filepath.Join(signer.Dir, "gpg.sh")
+	script := "#!/bin/sh\nexec gpg --batch --pinentry-mode loopback"
+	if passphrase != "" {
+		passphraseFile := filepath.Join(signer.Dir, "passphrase")
+		if err := os.WriteFile(passphraseFile, []byte(passphrase), 0600); err != nil {
+			return fmt.Errorf("failed to write passphrase: %v", err)
+		}
+		script += fmt.Sprintf(" --passphrase-file '%s'", passphraseFile)
+	}
+	script += " \"$@\"\n"
+	if err := os.WriteFile(program, []byte(script), 0700); err != nil {
+		return fmt.Errorf("failed to write gpg wrapper: %v", err)
 	}
 
+	signer.Config = []string{"-c", "gpg.format=openpgp", "-c", "gpg.program=" + program, "-c", "user.signingkey=" + fingerprint}
 	return nil
 }
 
-// createGitHubRelease creates a GitHub release with auto-generated notes
-func createGitHubRelease(config *Config, result *Result) (string, error) {
-	// Create release notes file with metadata
-	releaseNotes := fmt.Sprintf(`## What's Changed
-
-This release was created from commit %s on branch %s.
+// setupSSHSigner writes an OpenSSH private key, and an allowed signers file that
+// lets git tag -v verify tags signed by the tagger with it
+func setupSSHSigner(signer *Signer, key string, tagger Identity) error {
+	keyFile := filepath.Join(signer.Dir, "signing_key")
+	if !strings.HasSuffix(key, "\n") {
+		key += "\n"
+	}
+	if err := os.WriteFile(keyFile, []byte(key), 0600); err != nil {
+		return fmt.Errorf("failed to write signing-key: %v", err)
+	}
 
-### Version Details
-- **Previous version**: %s
-- **New version**: %s
-- **Version type**: %s release
-`, result.TargetCommit, config.Branch, result.PreviousVersion, result.NewVersion, result.IncrementType)
+	publicKey, err := exec.Command("ssh-keygen", "-y", "-f", keyFile).Output()
+	if err != nil {
+		return fmt.Errorf("signing-key is not a passphrase-free OpenSSH private key: %v", err)
+	}
 
-	// Write release notes to temporary file
-	releaseNotesFile := "release_notes.md"
-	if err := os.WriteFile(releaseNotesFile, []byte(releaseNotes), 0644); err != nil {
-		return "", fmt.Errorf("failed to write release notes file: %v", err)
+	allowedSigners := filepath.Join(signer.Dir, "allowed_signers")
+	entry := fmt.Sprintf("%s namespaces=\"git\" %s\n", tagger.Email, strings.TrimSpace(string(publicKey)))
+	if err := os.WriteFile(allowedSigners, []byte(entry), 0600); err != nil {
+		return fmt.Errorf("failed to write allowed signers: %v", err)
 	}
-	defer os.Remove(releaseNotesFile)
 
-	// Create release with auto-generated notes
-	cmd := exec.Command("gh", "release", "create", result.NewVersion,
-		"--title", result.NewVersion,
-		"--notes-file", releaseNotesFile,
-		"--generate-notes",
-		"--latest",
-		"--target", result.TargetCommit)
+	signer.Config = []string{"-c", "gpg.format=ssh", "-c", "user.signingkey=" + keyFile, "-c", "gpg.ssh.allowedSignersFile=" + allowedSigners}
+	return nil
+}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("failed to create release: %v\nOutput: %s", err, string(output))
+// Cleanup stops the gpg agent started for the key and removes the key
+func (s *Signer) Cleanup() {
+	if s == nil {
+		return
 	}
 
-	// Extract release URL from output
-	releaseURL := strings.TrimSpace(string(output))
-	actionskit.Info(fmt.Sprintf("✅ Created release %s with auto-generated notes", result.NewVersion))
+	if s.Format == "gpg" {
+		cmd := exec.Command("gpgconf", "--kill", "gpg-agent")
+		cmd.Env = append(os.Environ(), s.Env...)
+		cmd.Run() // Ignore error, the agent may never have started
+	}
 
-	return releaseURL, nil
+	os.RemoveAll(s.Dir)
 }
 
-// setOutputs sets the GitHub Actions outputs
-func setOutputs(result *Result) error {
-	outputs := map[string]string{
-		"previous-version": result.PreviousVersion,
-		"new-version":      result.NewVersion,
-		"increment-type":   result.IncrementType,
-		"release-url":      result.ReleaseURL,
-		"target-commit":    result.TargetCommit,
+// tagCommand builds a git command run as the tagger that, with a signer, signs
+// with its key
+func tagCommand(tagger Identity, signer *Signer, args ...string) *exec.Cmd {
+	if signer == nil {
+		return gitAs(tagger, args...)
 	}
 
-	for name, value := range outputs {
-		if err := actionskit.SetOutput(name, value); err != nil {
-			return fmt.Errorf("failed to set %s output: %v", name, err)
-		}
+	cmd := gitAs(tagger, append(append([]string{}, signer.Config...), args...)...)
+	cmd.Env = append(cmd.Env, signer.Env...)
+	return cmd
+}
+
+// verifyTag checks the signature of a tag just signed, doing nothing without a signer
+func verifyTag(tagger Identity, signer *Signer, tag string) error {
+	if signer == nil {
+		return nil
+	}
+
+	if output, err := tagCommand(tagger, signer, "tag", "-v", tag).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to verify the signature of tag %s: %v\nOutput: %s", tag, err, string(output))
+	}
+
+	actionskit.Info(fmt.Sprintf("✅ Verified the signature of tag %s", tag))
+	return nil
+}
+
+// updateChangelog adds a Keep a Changelog section for the new version to the
+// changelog file and, for changelog commit, commits and pushes it to the branch,
+// making that commit the release's target commit
+func updateChangelog(config *Config, result *Result, tagger Identity, newSemver *versionkit.SemanticVersion, previousTag string, commits []semveractions.Commit) error {
+	version := semveractions.FormatVersionWithPrefix(newSemver, "")
+	result.Changelog = semveractions.GenerateChangelogSection(semveractions.ChangelogRelease{
+		Version:       version,
+		Tag:           result.NewVersion,
+		PreviousTag:   previousTag,
+		Date:          time.Now().UTC().Format("2006-01-02"),
+		RepositoryURL: semveractions.GetRepositoryURL(),
+	}, commits)
+
+	// A resumed release already has its changelog commit, which the tag points at,
+	// so only the rendered section is needed for the outputs
+	if result.Resumed && config.Changelog == "commit" {
+		result.ChangelogCommit = result.TargetCommit
+		return nil
+	}
+
+	existing, err := os.ReadFile(config.ChangelogPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", config.ChangelogPath, err)
+	}
+	backup := &ChangelogBackup{Contents: existing, Existed: err == nil}
+
+	changelog, err := semveractions.InsertChangelogSection(string(existing), result.Changelog, version)
+	if err != nil {
+		return err
+	}
+
+	// A dry run only previews the section
+	if config.DryRun {
+		return nil
+	}
+
+	result.ChangelogBackup = backup
+	if err := os.WriteFile(config.ChangelogPath, []byte(changelog), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", config.ChangelogPath, err)
+	}
+	actionskit.Info(fmt.Sprintf("Updated %s for %s", config.ChangelogPath, version))
+
+	if config.Changelog != "commit" {
+		return nil
+	}
+
+	backup.Head, err = getTargetCommitSHA("HEAD")
+	if err != nil {
+		return err
+	}
+
+	if output, err := exec.Command("git", "add", config.ChangelogPath).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stage %s: %v\nOutput: %s", config.ChangelogPath, err, string(output))
+	}
+
+	message := fmt.Sprintf("chore(release): %s", result.NewVersion)
+	if output, err := gitAs(tagger, "commit", "-m", message).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit %s: %v\nOutput: %s", config.ChangelogPath, err, string(output))
+	}
+
+	// The commit is pushed along with the tags, so origin never gets it without them
+	changelogCommit, err := getTargetCommitSHA("HEAD")
+	if err != nil {
+		return err
+	}
+	result.ChangelogCommit = changelogCommit
+	result.TargetCommit = changelogCommit
+
+	actionskit.Info(fmt.Sprintf("✅ Committed %s as %s", config.ChangelogPath, changelogCommit))
+	return nil
+}
+
+// restoreChangelog puts the changelog file back as it was before the changelog update
+// and resets the local branch past the changelog commit, if one was made
+func restoreChangelog(config *Config, result *Result) error {
+	backup := result.ChangelogBackup
+	if backup == nil {
+		return nil
+	}
+
+	if backup.Head != "" {
+		if output, err := exec.Command("git", "reset", "--keep", backup.Head).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to reset to %s: %v\nOutput: %s", backup.Head, err, string(output))
+		}
+	}
+
+	if backup.Existed {
+		if err := os.WriteFile(config.ChangelogPath, backup.Contents, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", config.ChangelogPath, err)
+		}
+	} else if err := os.Remove(config.ChangelogPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %v", config.ChangelogPath, err)
+	}
+
+	result.ChangelogBackup = nil
+	actionskit.Info(fmt.Sprintf("Restored %s", config.ChangelogPath))
+	return nil
+}
+
+// getRemoteTags lists the tags on origin with the objects they point to
+func getRemoteTags() (map[string]string, error) {
+	cmd := exec.Command("git", "ls-remote", "--tags", "--refs", "origin")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags on origin: %v", err)
+	}
+
+	tags := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			tags[strings.TrimPrefix(fields[1], "refs/tags/")] = fields[0]
+		}
+	}
+
+	return tags, nil
+}
+
+// resolveFloatingTags decides which floating tags follow the new version: the major
+// tag (v1) and, with floating-tags major-minor, the minor tag (v1.4). Prereleases never
+// move them, 0.x versions only with float-major-zero, and a tag is left alone when a
+// higher stable version in its line exists, such as 1.2.5 released after 1.3.0.
+func resolveFloatingTags(tags []string, config *Config, newSemver *versionkit.SemanticVersion) []FloatingTag {
+	if config.FloatingTags == "none" {
+		return nil
+	}
+	if newSemver.PreReleaseVersion != "" {
+		actionskit.Info("Version is a prerelease, skipping floating version tag update")
+		return nil
+	}
+	if newSemver.MajorVersion < 1 && !config.FloatMajorZero {
+		actionskit.Info("Major version is 0, skipping floating version tag creation")
+		return nil
+	}
+
+	candidates := []FloatingTag{
+		{Name: fmt.Sprintf("%s%d", config.Prefix, newSemver.MajorVersion), Level: "major"},
+	}
+	if config.FloatingTags == "major-minor" {
+		candidates = append(candidates, FloatingTag{Name: fmt.Sprintf("%s%d.%d", config.Prefix, newSemver.MajorVersion, newSemver.MinorVersion), Level: "minor"})
+	}
+
+	var floatingTags []FloatingTag
+	for _, candidate := range candidates {
+		if newer, found := findNewerInLine(tags, config.Prefix, newSemver, candidate.Level); found {
+			actionskit.Info(fmt.Sprintf("Tag %s is newer, skipping %s version tag update for %s", newer, candidate.Level, semveractions.FormatVersionWithPrefix(newSemver, config.Prefix)))
+			continue
+		}
+		floatingTags = append(floatingTags, candidate)
+	}
+
+	return floatingTags
+}
+
+// findNewerInLine finds a stable version tag higher than the new version in its major
+// line or, for the minor level, its major.minor line
+func findNewerInLine(tags []string, prefix string, newSemver *versionkit.SemanticVersion, level string) (string, bool) {
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+
+		semver, _, err := semveractions.ParseVersionWithPrefix(tag, prefix)
+		if err != nil || semver.PreReleaseVersion != "" || semver.MajorVersion != newSemver.MajorVersion {
+			continue // Only stable versions in the same line count
+		}
+		if level == "minor" && semver.MinorVersion != newSemver.MinorVersion {
+			continue
+		}
+
+		if semver.Compare(*newSemver) > 0 {
+			return tag, true
+		}
+	}
+
+	return "", false
+}
+
+// createAndPushTags creates the semver tag, unless it exists from an earlier run, and
+// moves the given floating tags to it, then pushes them all atomically so origin gets
+// either every tag or none. When branch is set, the target commit is pushed to it in
+// the same push.
+func createAndPushTags(newVersionTag, tagMessage, targetCommit, branch string, floatingTags []FloatingTag, tagger Identity, signer *Signer, lightweight, resumed bool) error {
+	// Tags are annotated, signed when a signer is set up, or lightweight if asked to
+	annotate := "-a"
+	if signer != nil {
+		annotate = "-s"
+	}
+	tagArgs := func(tag, message string, force bool) []string {
+		args := []string{"tag"}
+		if force {
+			args = append(args, "-f")
+		}
+		if !lightweight {
+			args = append(args, annotate, "-m", message)
+		}
+		return append(args, tag, targetCommit)
+	}
+
+	// Create the semver tag
+	if !resumed {
+		if output, err := tagCommand(tagger, signer, tagArgs(newVersionTag, tagMessage, false)...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to create tag %s: %v\nOutput: %s", newVersionTag, err, string(output))
+		}
+		if err := verifyTag(tagger, signer, newVersionTag); err != nil {
+			return err
+		}
+	}
+
+	// The new tag must not exist on origin, while floating tags are force-updated
+	refspecs := []string{"refs/tags/" + newVersionTag}
+	for _, floatingTag := range floatingTags {
+		actionskit.Info(fmt.Sprintf("Creating %s version tag: %s", floatingTag.Level, floatingTag.Name))
+
+		// Fetch the tag being moved so a rollback can push it back
+		if floatingTag.Previous != "" {
+			if output, err := exec.Command("git", "fetch", "--no-tags", "origin", "refs/tags/"+floatingTag.Name).CombinedOutput(); err != nil {
+				return fmt.Errorf("failed to fetch %s tag %s: %v\nOutput: %s", floatingTag.Level, floatingTag.Name, err, string(output))
+			}
+		}
+
+		if output, err := tagCommand(tagger, signer, tagArgs(floatingTag.Name, floatingTag.Message, true)...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to create %s tag %s: %v\nOutput: %s", floatingTag.Level, floatingTag.Name, err, string(output))
+		}
+		if err := verifyTag(tagger, signer, floatingTag.Name); err != nil {
+			return err
+		}
+
+		refspecs = append(refspecs, fmt.Sprintf("+refs/tags/%s:refs/tags/%s", floatingTag.Name, floatingTag.Name))
+	}
+
+	if branch != "" {
+		refspecs = append(refspecs, fmt.Sprintf("%s:refs/heads/%s", targetCommit, branch))
+	}
+
+	args := append([]string{"push", "--atomic", "origin"}, refspecs...)
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to push tags: %v\nOutput: %s", err, string(output))
+	}
+
+	if branch != "" {
+		actionskit.Info(fmt.Sprintf("✅ Pushed changelog commit %s to %s", targetCommit, branch))
+	}
+	actionskit.Info(fmt.Sprintf("✅ Created and pushed tag %s for commit %s", newVersionTag, targetCommit))
+	for _, floatingTag := range floatingTags {
+		actionskit.Info(fmt.Sprintf("✅ Created and pushed %s version tag %s", floatingTag.Level, floatingTag.Name))
+	}
+
+	return nil
+}
+
+// rollbackRelease undoes a release that failed after its tags were pushed, so the
+// next run does not fail with "tag already exists": it deletes the release if one
+// was created and the new tag, moves floating tags back, resets the branch past
+// the changelog commit and restores the changelog file. With rollback false
+// everything is left in place for inspection.
+func rollbackRelease(config *Config, result *Result) {
+	if !config.Rollback {
+		actionskit.Warning(fmt.Sprintf("rollback is false, leaving tag %s in place", result.NewVersion))
+		return
+	}
+
+	if result.ReleaseID != 0 {
+		if err := deleteGitHubRelease(config, result.ReleaseID); err != nil {
+			result.Error = fmt.Errorf("%v; rollback failed to delete release %d: %v", result.Error, result.ReleaseID, err)
+			return
+		}
+		actionskit.Info(fmt.Sprintf("Deleted release %s (ID %d)", result.NewVersion, result.ReleaseID))
+	}
+
+	// A resumed release's tag and changelog commit predate this run, so only the
+	// floating tags are undone
+	versionTag, changelogBranch := result.NewVersion, config.Branch
+	if result.Resumed {
+		versionTag = ""
+	}
+	if result.ChangelogCommit == "" || result.Resumed {
+		changelogBranch = ""
+	}
+
+	if err := rollbackRefs(versionTag, result.FloatingTags, changelogBranch, result.ChangelogCommit); err != nil {
+		result.Error = fmt.Errorf("%v; rollback failed: %v", result.Error, err)
+		return
+	}
+	if err := restoreChangelog(config, result); err != nil {
+		result.Error = fmt.Errorf("%v; rollback failed to restore %s: %v", result.Error, config.ChangelogPath, err)
+		return
+	}
+
+	result.RolledBack = true
+	result.MajorTagUpdated = false
+	if result.Resumed {
+		actionskit.Info(fmt.Sprintf("Rolled back floating tags for %s", result.NewVersion))
+	} else {
+		actionskit.Info(fmt.Sprintf("Rolled back tag %s", result.NewVersion))
+	}
+	if changelogBranch != "" {
+		actionskit.Info(fmt.Sprintf("Reset %s past changelog commit %s", changelogBranch, result.ChangelogCommit))
+	}
+}
+
+// rollbackRefs atomically deletes the new tag, if given, from origin and restores each
+// floating tag to where it pointed before, deleting those that did not exist, then
+// does the same locally. When branch is set it is also moved back to the parent of
+// changelogCommit on origin, provided nothing was pushed on top of it since.
+func rollbackRefs(newVersionTag string, floatingTags []FloatingTag, branch, changelogCommit string) error {
+	args := []string{"push", "--atomic"}
+	var refspecs []string
+	if branch != "" {
+		args = append(args, fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", branch, changelogCommit))
+		refspecs = append(refspecs, fmt.Sprintf("%s^:refs/heads/%s", changelogCommit, branch))
+	}
+	if newVersionTag != "" {
+		refspecs = append(refspecs, ":refs/tags/"+newVersionTag)
+	}
+	for _, floatingTag := range floatingTags {
+		if floatingTag.Previous == "" {
+			refspecs = append(refspecs, ":refs/tags/"+floatingTag.Name)
+		} else {
+			refspecs = append(refspecs, fmt.Sprintf("+%s:refs/tags/%s", floatingTag.Previous, floatingTag.Name))
+		}
+	}
+
+	if len(refspecs) == 0 {
+		return nil
+	}
+
+	args = append(append(args, "origin"), refspecs...)
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to restore refs on origin: %v\nOutput: %s", err, string(output))
+	}
+
+	if newVersionTag != "" {
+		exec.Command("git", "tag", "-d", newVersionTag).Run() // Ignore error, origin is what matters
+	}
+	for _, floatingTag := range floatingTags {
+		if floatingTag.Previous == "" {
+			exec.Command("git", "tag", "-d", floatingTag.Name).Run()
+		} else {
+			exec.Command("git", "update-ref", "refs/tags/"+floatingTag.Name, floatingTag.Previous).Run()
+		}
+	}
+
+	return nil
+}
+
+// resolveMakeLatest decides whether the new release is marked latest. Prereleases
+// and drafts never are; with make-latest auto, a release is only when no published stable
+// release has a higher version, so a backport does not take the badge away.
+func resolveMakeLatest(config *Config, result *Result, newSemver *versionkit.SemanticVersion) (bool, error) {
+	if result.Prerelease || config.Draft {
+		return false, nil
+	}
+	if config.MakeLatest != "auto" {
+		return config.MakeLatest == "true", nil
+	}
+
+	releases, err := listReleases(config.Repository, config.GitHubToken)
+	if err != nil {
+		return false, fmt.Errorf("failed to list releases: %v", err)
+	}
+
+	for _, release := range releases {
+		if release.Draft || release.Prerelease || !strings.HasPrefix(release.TagName, config.Prefix) {
+			continue
+		}
+
+		semver, _, err := semveractions.ParseVersionWithPrefix(release.TagName, config.Prefix)
+		if err != nil || semver.PreReleaseVersion != "" {
+			continue // Skip releases without a stable version tag
+		}
+
+		if semver.Compare(*newSemver) > 0 {
+			actionskit.Info(fmt.Sprintf("Release %s is newer, %s will not be marked latest", release.TagName, result.NewVersion))
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// listReleases lists all releases of a repository, following pagination
+func listReleases(repository, token string) ([]Release, error) {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+
+	var releases []Release
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/releases?per_page=100&page=%d", apiBase, repository, page)
+
+		// Create request
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Set headers
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		// Make request
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check status
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("API request failed with status %d: %s",
+				resp.StatusCode, string(body))
+		}
+
+		// Parse response
+		var pageReleases []Release
+		err = json.NewDecoder(resp.Body).Decode(&pageReleases)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		releases = append(releases, pageReleases...)
+		if len(pageReleases) < 100 {
+			return releases, nil
+		}
+	}
+}
+
+// createGitHubRelease creates a GitHub release for the new tag with the rendered
+// release notes, as a draft if asked to
+func createGitHubRelease(config *Config, result *Result, releaseNotes string) (*Release, error) {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/releases", apiBase, config.Repository)
+
+	// Create request body
+	request := CreateReleaseRequest{
+		TagName:         result.NewVersion,
+		TargetCommitish: result.TargetCommit,
+		Name:            result.NewVersion,
+		Body:            releaseNotes,
+		Draft:           config.Draft,
+		Prerelease:      result.Prerelease,
+		MakeLatest:      strconv.FormatBool(result.Latest),
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if config.GitHubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+config.GitHubToken)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, err
+	}
+
+	kind := "release"
+	switch {
+	case config.Draft:
+		kind = "draft release"
+	case result.Prerelease:
+		kind = "prerelease"
+	case result.Latest:
+		kind = "latest release"
+	}
+	actionskit.Info(fmt.Sprintf("✅ Created %s %s (ID %d)", kind, result.NewVersion, release.ID))
+	return &release, nil
+}
+
+// getAuthenticatedUser gets the user the token belongs to
+func getAuthenticatedUser(token string) (*User, error) {
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+
+	req, err := http.NewRequest("GET", apiBase+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body))
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// deleteGitHubRelease deletes a release, leaving its tag alone
+func deleteGitHubRelease(config *Config, id int64) error {
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/releases/%d", apiBase, config.Repository, id)
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if config.GitHubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+config.GitHubToken)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// resolveAssets expands asset glob patterns into the files to upload. Each pattern
+// must match at least one file, and the files must have unique names.
+func resolveAssets(patterns []string) ([]string, error) {
+	var files []string
+	names := make(map[string]string)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assets pattern %q: %v", pattern, err)
+		}
+
+		matchedFile := false
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if info.IsDir() {
+				continue
+			}
+			matchedFile = true
+
+			name := filepath.Base(match)
+			if previous, ok := names[name]; ok {
+				if previous == match {
+					continue
+				}
+				return nil, fmt.Errorf("assets %s and %s have the same name", previous, match)
+			}
+			if name == checksumsAssetName {
+				return nil, fmt.Errorf("asset %s clashes with the generated %s", match, checksumsAssetName)
+			}
+			names[name] = match
+			files = append(files, match)
+		}
+
+		if !matchedFile {
+			return nil, fmt.Errorf("assets pattern %q matched no files", pattern)
+		}
+	}
+	return files, nil
+}
+
+// uploadAssets uploads the asset files and a SHA256SUMS file listing the checksums of
+// those uploaded to the release. With continue-on-asset-error a failed upload is a warning and the
+// asset is left out; otherwise it fails the upload.
+func uploadAssets(config *Config, uploadURL string, files []string) ([]Asset, error) {
+	var assets []Asset
+	var checksums strings.Builder
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read asset %s: %v", file, err)
+		}
+
+		name := filepath.Base(file)
+		asset, err := uploadAsset(uploadURL, name, assetContentType(name), content, config.GitHubToken)
+		if err != nil {
+			if !config.ContinueOnAssetError {
+				return nil, fmt.Errorf("failed to upload asset %s: %v", name, err)
+			}
+			actionskit.Warning(fmt.Sprintf("Failed to upload asset %s: %v", name, err))
+			continue
+		}
+
+		actionskit.Info(fmt.Sprintf("✅ Uploaded asset %s", name))
+		assets = append(assets, *asset)
+		fmt.Fprintf(&checksums, "%x  %s\n", sha256.Sum256(content), name)
+	}
+
+	asset, err := uploadAsset(uploadURL, checksumsAssetName, "text/plain", []byte(checksums.String()), config.GitHubToken)
+	if err != nil {
+		if !config.ContinueOnAssetError {
+			return nil, fmt.Errorf("failed to upload asset %s: %v", checksumsAssetName, err)
+		}
+		actionskit.Warning(fmt.Sprintf("Failed to upload asset %s: %v", checksumsAssetName, err))
+		return assets, nil
+	}
+
+	actionskit.Info(fmt.Sprintf("✅ Uploaded asset %s", checksumsAssetName))
+	return append(assets, *asset), nil
+}
+
+// assetContentType returns the content type for an asset from its extension
+func assetContentType(name string) string {
+	extension := strings.ToLower(filepath.Ext(name))
+	if contentType, ok := assetContentTypes[extension]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// uploadAsset uploads a file to a release through its upload URL template
+func uploadAsset(uploadURL, name, contentType string, content []byte, token string) (*Asset, error) {
+	// Expand the upload URL template, such as ".../assets{?name,label}"
+	if i := strings.Index(uploadURL, "{"); i >= 0 {
+		uploadURL = uploadURL[:i]
+	}
+	url := uploadURL + "?name=" + neturl.QueryEscape(name)
+
+	// Create HTTP request
+	req, err := http.NewRequest("POST", url, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var asset Asset
+	if err := json.Unmarshal(body, &asset); err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+// TagMessage is the data available to the tag-message and floating-tag-message templates
+type TagMessage struct {
+	Tag             string // The version tag, such as v1.4.0
+	Version         string // The version without the prefix, such as 1.4.0
+	Major           uint
+	Minor           uint
+	Patch           uint
+	Prerelease      string
+	PreviousVersion string // "none" for a first release
+	IncrementType   string
+	Commit          string
+	ShortCommit     string
+	Shortlog        string // The commits since the previous version by author, like git shortlog
+	FloatingTag     string // The floating tag, such as v1, for floating-tag-message only
+	Level           string // major or minor, for floating-tag-message only
+}
+
+// The tag messages when tag-message and floating-tag-message are not given
+const (
+	defaultTagMessageTemplate         = "Release {{.Tag}}"
+	defaultFloatingTagMessageTemplate = `{{if eq .Level "minor"}}Minor{{else}}Major{{end}} version {{.FloatingTag}} (latest: {{.Tag}})`
+)
+
+// ReleaseNotes is the data available to a release-notes-template
+type ReleaseNotes struct {
+	Version         string
+	PreviousVersion string // "none" for a first release
+	IncrementType   string
+	TargetCommit    string
+	Branch          string
+	CompareURL      string // Empty for a first release or outside GitHub Actions
+	Categories      []ReleaseNotesCategory
+	Contributors    []string // @login for pull request authors, otherwise the commit author's name
+	NewContributors []ReleaseNotesContributor
+}
+
+// ReleaseNotesCategory is the changes of one Conventional Commits type
+type ReleaseNotesCategory struct {
+	Title   string
+	Changes []ReleaseNotesChange
+}
+
+// ReleaseNotesChange is a commit in the release notes, with its pull request if it has one
+type ReleaseNotesChange struct {
+	Scope          string
+	Description    string
+	Author         string
+	SHA            string
+	ShortSHA       string
+	CommitURL      string
+	PullRequest    int
+	PullRequestURL string
+}
+
+// ReleaseNotesContributor is an author whose first commit is in the release
+type ReleaseNotesContributor struct {
+	Name string
+	URL  string // Pull request or commit of the first contribution
+}
+
+// defaultReleaseNotesTemplate lays out the release notes when no
+// release-notes-template is given
+const defaultReleaseNotesTemplate = `## What's Changed
+{{- range .Categories}}
+
+### {{.Title}}
+{{range .Changes}}
+* {{if .Scope}}**{{.Scope}}:** {{end}}{{.Description}}{{if .Author}} by {{.Author}}{{end}}{{if .PullRequestURL}} in {{.PullRequestURL}}{{else if .CommitURL}} in {{.CommitURL}}{{else}} in {{.ShortSHA}}{{end}}
+{{- end}}
+{{- else}}
+
+No notable changes.
+{{- end}}
+{{- if .NewContributors}}
+
+## New Contributors
+{{range .NewContributors}}
+* {{.Name}} made their first contribution{{if .URL}} in {{.URL}}{{end}}
+{{- end}}
+{{- end}}
+{{- if .Contributors}}
+
+## Contributors
+
+{{range $i, $contributor := .Contributors}}{{if $i}}, {{end}}{{$contributor}}{{end}}
+{{- end}}
+{{- if .CompareURL}}
+
+**Full Changelog**: {{.CompareURL}}
+{{- end}}
+`
+
+// buildReleaseNotes collects the release notes data from the commits since the
+// previous tag and, when the repository is known, their merged pull requests
+func buildReleaseNotes(config *Config, result *Result, previousTag string, commits []semveractions.Commit, lookup *PullRequestLookup) (*ReleaseNotes, error) {
+	repositoryURL := semveractions.GetRepositoryURL()
+	notes := &ReleaseNotes{
+		Version:         result.NewVersion,
+		PreviousVersion: result.PreviousVersion,
+		IncrementType:   result.IncrementType,
+		TargetCommit:    result.TargetCommit,
+		Branch:          config.Branch,
+	}
+	if repositoryURL != "" && previousTag != "" {
+		notes.CompareURL = fmt.Sprintf("%s/compare/%s...%s", repositoryURL, previousTag, result.NewVersion)
+	}
+
+	// Describe each commit once, with the author and link of its pull request
+	changes := make(map[string]ReleaseNotesChange)
+	for _, commit := range commits {
+		change := ReleaseNotesChange{
+			Author:   commit.Author,
+			SHA:      commit.SHA,
+			ShortSHA: commit.ShortSHA(),
+		}
+		if repositoryURL != "" {
+			change.CommitURL = fmt.Sprintf("%s/commit/%s", repositoryURL, commit.SHA)
+		}
+
+		if config.Repository != "" {
+			pullRequests, err := lookup.ForCommit(commit.SHA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests for commit %s: %v", commit.SHA, err)
+			}
+			for _, pullRequest := range pullRequests {
+				if pullRequest.MergedAt == nil {
+					continue
+				}
+				change.PullRequest = pullRequest.Number
+				change.PullRequestURL = pullRequest.HTMLURL
+				if pullRequest.User.Login != "" {
+					change.Author = "@" + pullRequest.User.Login
+				}
+				break
+			}
+		}
+
+		changes[commit.SHA] = change
+	}
+
+	for _, group := range semveractions.GroupChangelogEntries(commits) {
+		category := ReleaseNotesCategory{Title: group.Heading}
+		for _, entry := range group.Entries {
+			change := changes[entry.Commit.SHA]
+			change.Scope = entry.Scope
+			change.Description = entry.Description
+			if change.PullRequest == 0 && entry.PullRequest != 0 {
+				change.PullRequest = entry.PullRequest
+				if repositoryURL != "" {
+					change.PullRequestURL = fmt.Sprintf("%s/pull/%d", repositoryURL, entry.PullRequest)
+				}
+			}
+			category.Changes = append(category.Changes, change)
+		}
+		notes.Categories = append(notes.Categories, category)
+	}
+
+	// Credit every author, and call out those whose first commit is in this release;
+	// on a first release everyone is new, so nobody is called out
+	var previousAuthors map[string]bool
+	if previousTag != "" {
+		var err error
+		previousAuthors, err = semveractions.GetAuthorEmails(previousTag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		change := changes[commit.SHA]
+		if change.Author == "" || seen[change.Author] {
+			continue
+		}
+		seen[change.Author] = true
+		notes.Contributors = append(notes.Contributors, change.Author)
+
+		if previousAuthors != nil && !previousAuthors[strings.ToLower(commit.AuthorEmail)] {
+			url := change.PullRequestURL
+			if url == "" {
+				url = change.CommitURL
+			}
+			notes.NewContributors = append(notes.NewContributors, ReleaseNotesContributor{Name: change.Author, URL: url})
+		}
+	}
+	sort.Slice(notes.Contributors, func(i, j int) bool {
+		return strings.ToLower(notes.Contributors[i]) < strings.ToLower(notes.Contributors[j])
+	})
+
+	return notes, nil
+}
+
+// renderReleaseNotes executes a release-notes-template with the release notes data
+func renderReleaseNotes(text string, notes *ReleaseNotes) (string, error) {
+	tmpl, err := template.New("release-notes").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid release-notes-template: %v", err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, notes); err != nil {
+		return "", fmt.Errorf("invalid release-notes-template: %v", err)
+	}
+	return rendered.String(), nil
+}
+
+// renderTagMessage executes the named tag message template with the tag data
+func renderTagMessage(name, text string, message TagMessage) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %v", name, err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, message); err != nil {
+		return "", fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return rendered.String(), nil
+}
+
+// formatShortlog summarizes commits like git shortlog: authors in alphabetical order,
+// each with a count and their commit subjects, oldest first
+func formatShortlog(commits []semveractions.Commit) string {
+	subjects := map[string][]string{}
+	var authors []string
+	for i := len(commits) - 1; i >= 0; i-- {
+		author := commits[i].Author
+		if _, seen := subjects[author]; !seen {
+			authors = append(authors, author)
+		}
+		subjects[author] = append(subjects[author], commits[i].Subject)
+	}
+	sort.Strings(authors)
+
+	var shortlog strings.Builder
+	for i, author := range authors {
+		if i > 0 {
+			shortlog.WriteString("\n")
+		}
+		fmt.Fprintf(&shortlog, "%s (%d):\n", author, len(subjects[author]))
+		for _, subject := range subjects[author] {
+			fmt.Fprintf(&shortlog, "      %s\n", subject)
+		}
+	}
+	return strings.TrimSuffix(shortlog.String(), "\n")
+}
+
+// skipLabeledCommits leaves out the commits associated with a merged pull request
+// that has the skip label
+func skipLabeledCommits(commits []semveractions.Commit, config *Config, lookup *PullRequestLookup) ([]semveractions.Commit, error) {
+	var kept []semveractions.Commit
+	for _, commit := range commits {
+		pullRequests, err := lookup.ForCommit(commit.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests for commit %s: %v", commit.SHA, err)
+		}
+
+		skipped := false
+		for _, pullRequest := range pullRequests {
+			if pullRequest.MergedAt != nil && hasLabel(pullRequest, config.SkipLabel) {
+				skipped = true
+				actionskit.Info(fmt.Sprintf("Skipping %s %s (pull request #%d has the %s label)", commit.SHA, commit.Subject, pullRequest.Number, config.SkipLabel))
+				break
+			}
+		}
+		if !skipped {
+			kept = append(kept, commit)
+		}
+	}
+	return kept, nil
+}
+
+// labelIncrement resolves an auto increment from the merged pull requests associated
+// with each commit, taking the highest of their major, minor and patch labels. Pull
+// requests without any of the labels do not count towards the increment, which is
+// "none" when no pull request does.
+func labelIncrement(commits []semveractions.Commit, config *Config, lookup *PullRequestLookup) (string, []semveractions.Commit, error) {
+	increments := []string{"none", "patch", "minor", "major"}
+	rank := 0
+	var causes []semveractions.Commit
+	seen := make(map[int]bool)
+
+	for _, commit := range commits {
+		pullRequests, err := lookup.ForCommit(commit.SHA)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to list pull requests for commit %s: %v", commit.SHA, err)
+		}
+
+		// A pull request counts once, for the first (newest) of its commits
+		commitRank := 0
+		for _, pullRequest := range pullRequests {
+			if pullRequest.MergedAt == nil || seen[pullRequest.Number] {
+				continue
+			}
+			seen[pullRequest.Number] = true
+
+			if pullRequestRank := labelRank(pullRequest, config); pullRequestRank > commitRank {
+				commitRank = pullRequestRank
+			}
+		}
+
+		if commitRank == 0 || commitRank < rank {
+			continue
+		}
+		if commitRank > rank {
+			rank = commitRank
+			causes = nil
+		}
+		causes = append(causes, commit)
+	}
+
+	return increments[rank], causes, nil
+}
+
+// labelRank ranks a pull request by its semver label: 3 for major, 2 for minor,
+// 1 for patch, and 0 when it has none of them
+func labelRank(pullRequest PullRequest, config *Config) int {
+	switch {
+	case hasLabel(pullRequest, config.MajorLabel):
+		return 3
+	case hasLabel(pullRequest, config.MinorLabel):
+		return 2
+	case hasLabel(pullRequest, config.PatchLabel):
+		return 1
+	}
+	return 0
+}
+
+// hasLabel reports whether a pull request has the named label
+func hasLabel(pullRequest PullRequest, name string) bool {
+	for _, label := range pullRequest.Labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// firstReleasePullRequestLookups caps the commits whose pull requests are looked up
+// for a first release, whose commits are the repository's entire history
+const firstReleasePullRequestLookups = 100
+
+// PullRequestLookup lists the pull requests associated with each commit at most once
+// per run, so the label increment and the release notes share the API calls
+type PullRequestLookup struct {
+	repository string
+	token      string
+	limit      int // The most commits to look up, or 0 for no limit
+	bySHA      map[string][]PullRequest
+}
+
+// NewPullRequestLookup creates a lookup for the configured repository that looks up
+// at most limit commits, or every commit when limit is 0
+func NewPullRequestLookup(config *Config, limit int) *PullRequestLookup {
+	return &PullRequestLookup{
+		repository: config.Repository,
+		token:      config.GitHubToken,
+		limit:      limit,
+		bySHA:      make(map[string][]PullRequest),
+	}
+}
+
+// ForCommit returns the pull requests associated with a commit, or none once the
+// limit of commits has been looked up
+func (l *PullRequestLookup) ForCommit(sha string) ([]PullRequest, error) {
+	if pullRequests, ok := l.bySHA[sha]; ok {
+		return pullRequests, nil
+	}
+	if l.limit > 0 && len(l.bySHA) >= l.limit {
+		return nil, nil
+	}
+
+	pullRequests, err := listCommitPullRequests(l.repository, sha, l.token)
+	if err != nil {
+		return nil, err
+	}
+	l.bySHA[sha] = pullRequests
+	return pullRequests, nil
+}
+
+// listCommitPullRequests lists the pull requests associated with a commit
+func listCommitPullRequests(repository, sha, token string) ([]PullRequest, error) {
+	// Build API URL
+	apiBase := os.Getenv("GITHUB_API_URL")
+	if apiBase == "" {
+		apiBase = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/commits/%s/pulls", apiBase, repository, sha)
+
+	// Create request
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Make request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Check status
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API request failed with status %d: %s",
+			resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var pullRequests []PullRequest
+	if err := json.NewDecoder(resp.Body).Decode(&pullRequests); err != nil {
+		return nil, err
+	}
+
+	return pullRequests, nil
+}
+
+// setOutputs sets the GitHub Actions outputs
+func setOutputs(result *Result) error {
+	var floatingTagNames []string
+	for _, floatingTag := range result.FloatingTags {
+		floatingTagNames = append(floatingTagNames, floatingTag.Name)
+	}
+
+	outputs := map[string]string{
+		"previous-version":  result.PreviousVersion,
+		"new-version":       result.NewVersion,
+		"build-version":     result.BuildVersion,
+		"increment-type":    result.IncrementType,
+		"release-url":       result.ReleaseURL,
+		"release-id":        "",
+		"html-url":          result.ReleaseURL,
+		"upload-url":        result.UploadURL,
+		"latest":            strconv.FormatBool(result.Latest),
+		"major-tag-updated": strconv.FormatBool(result.MajorTagUpdated),
+		"floating-tags":     strings.Join(floatingTagNames, ","),
+		"resumed":           strconv.FormatBool(result.Resumed),
+		"dry-run":           strconv.FormatBool(result.DryRun),
+		"target-commit":     result.TargetCommit,
+		"changelog-commit":  result.ChangelogCommit,
+	}
+
+	if result.ReleaseID != 0 {
+		outputs["release-id"] = strconv.FormatInt(result.ReleaseID, 10)
+	}
+
+	// List each asset's name, content type and download URL, [] without assets
+	type assetOutput struct {
+		Name        string `json:"name"`
+		ContentType string `json:"content_type"`
+		URL         string `json:"url"`
+	}
+	assetOutputs := []assetOutput{}
+	for _, asset := range result.Assets {
+		assetOutputs = append(assetOutputs, assetOutput{Name: asset.Name, ContentType: asset.ContentType, URL: asset.BrowserDownloadURL})
+	}
+
+	assetsJSON, err := json.Marshal(assetOutputs)
+	if err != nil {
+		return fmt.Errorf("failed to encode assets-json: %v", err)
+	}
+	outputs["assets-json"] = string(assetsJSON)
+
+	// Encode no commits as an empty array rather than null
+	incrementCommits := result.IncrementCommits
+	if incrementCommits == nil {
+		incrementCommits = []semveractions.Commit{}
+	}
+
+	incrementCommitsJSON, err := json.Marshal(incrementCommits)
+	if err != nil {
+		return fmt.Errorf("failed to encode increment-commits: %v", err)
+	}
+	outputs["increment-commits"] = string(incrementCommitsJSON)
+
+	for name, value := range outputs {
+		if err := actionskit.SetOutput(name, value); err != nil {
+			return fmt.Errorf("failed to set %s output: %v", name, err)
+		}
+	}
+
+	if err := semveractions.SetMultilineOutput("changelog", result.Changelog); err != nil {
+		return fmt.Errorf("failed to set changelog output: %v", err)
+	}
+
+	if err := semveractions.SetMultilineOutput("tag-message", result.TagMessage); err != nil {
+		return fmt.Errorf("failed to set tag-message output: %v", err)
+	}
+
+	if err := semveractions.SetMultilineOutput("release-notes", result.ReleaseNotes); err != nil {
+		return fmt.Errorf("failed to set release-notes output: %v", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// renderDryRunSummary renders the Markdown job summary previewing a dry run
+func renderDryRunSummary(result *Result) string {
+	var floatingTags []string
+	for _, floatingTag := range result.FloatingTags {
+		floatingTags = append(floatingTags, floatingTag.Name)
+	}
+	if len(floatingTags) == 0 {
+		floatingTags = []string{"none"}
+	}
+
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "## Dry run: %s\n\n", result.NewVersion)
+	summary.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&summary, "| Previous version | %s |\n", result.PreviousVersion)
+	fmt.Fprintf(&summary, "| New version | %s |\n", result.NewVersion)
+	fmt.Fprintf(&summary, "| Build version | %s |\n", result.BuildVersion)
+	fmt.Fprintf(&summary, "| Increment | %s |\n", result.IncrementType)
+	fmt.Fprintf(&summary, "| Target commit | %s |\n", result.TargetCommit)
+	fmt.Fprintf(&summary, "| Prerelease | %t |\n", result.Prerelease)
+	fmt.Fprintf(&summary, "| Latest | %t |\n", result.Latest)
+	fmt.Fprintf(&summary, "| Floating tags | %s |\n", strings.Join(floatingTags, ", "))
+
+	summary.WriteString("\n### Tag messages\n\n")
+	if result.TagMessage == "" {
+		summary.WriteString("Lightweight tags, without messages.\n\n")
+	} else {
+		fmt.Fprintf(&summary, "**%s**\n\n```\n%s\n```\n\n", result.NewVersion, result.TagMessage)
+		for _, floatingTag := range result.FloatingTags {
+			fmt.Fprintf(&summary, "**%s**\n\n```\n%s\n```\n\n", floatingTag.Name, floatingTag.Message)
+		}
+	}
+
+	if result.Changelog != "" {
+		fmt.Fprintf(&summary, "### Changelog\n\n%s\n\n", strings.TrimSpace(result.Changelog))
+	}
+
+	fmt.Fprintf(&summary, "### Release notes\n\n%s\n", strings.TrimSpace(result.ReleaseNotes))
+	return summary.String()
+}
+
+// writeJobSummary appends Markdown to the job summary, doing nothing outside of
+// GitHub Actions where GITHUB_STEP_SUMMARY is unset
+func writeJobSummary(markdown string) error {
+	summaryFile := os.Getenv("GITHUB_STEP_SUMMARY")
+	if summaryFile == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(summaryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening job summary file: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(markdown + "\n"); err != nil {
+		return fmt.Errorf("error writing to job summary file: %v", err)
+	}
+
+	return nil
+}